@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -59,6 +60,11 @@ func main() {
 		}
 	}
 
+	// Roll back in reverse order so later migrations are undone first
+	if direction == "down" {
+		sort.Sort(sort.Reverse(sort.StringSlice(migrationFiles)))
+	}
+
 	if len(migrationFiles) == 0 {
 		log.Fatalf("No %s migration files found in %s", direction, migrationsDir)
 	}
@@ -15,9 +15,13 @@ import (
 	"task-svc/internal/config"
 	httphandlers "task-svc/internal/http"
 	"task-svc/internal/platform/db"
+	"task-svc/internal/platform/httpclient"
 	applog "task-svc/internal/platform/log"
 	"task-svc/internal/platform/metrics"
+	"task-svc/internal/platform/storage"
+	"task-svc/internal/realtime"
 	"task-svc/internal/repo"
+	"task-svc/internal/scheduler"
 	"task-svc/internal/service"
 )
 
@@ -48,12 +52,110 @@ func main() {
 
 	// Setup repositories
 	taskRepo := repo.NewTaskRepo(dbPool)
+	statusRepo := repo.NewStatusRepo(dbPool)
+	labelRepo := repo.NewLabelRepo(dbPool)
+	userRepo := repo.NewUserRepo(dbPool)
+	projectRepo := repo.NewProjectRepo(dbPool)
+	viewRepo := repo.NewViewRepo(dbPool)
+	commentRepo := repo.NewCommentRepo(dbPool)
+	attachmentRepo := repo.NewAttachmentRepo(dbPool)
+	reminderRepo := repo.NewReminderRepo(dbPool)
+	webhookRepo := repo.NewWebhookRepo(dbPool)
+	outboxRepo := repo.NewOutboxRepo(dbPool)
+
+	// Setup blob storage for attachment contents
+	blobStore, err := storage.New(cfg.Storage)
+	if err != nil {
+		logger.Error("Failed to set up attachment storage", "error", err)
+		os.Exit(1)
+	}
+
+	// Setup the HTTP client for outgoing webhook deliveries; the delivery
+	// queue does its own retries
+	webhookClient, err := httpclient.New(httpclient.ClientConfig{
+		Timeout:        cfg.Webhooks.Timeout,
+		DefaultHeaders: map[string]string{"User-Agent": "task-svc-webhooks/1"},
+	})
+	if err != nil {
+		logger.Error("Failed to set up webhook client", "error", err)
+		os.Exit(1)
+	}
 
 	// Setup services
-	taskService := service.NewTaskService(taskRepo)
+	workflow, err := service.NewWorkflow(cfg.Workflow)
+	if err != nil {
+		logger.Error("Invalid workflow configuration", "error", err)
+		os.Exit(1)
+	}
+	webhookService := service.NewWebhookService(webhookRepo, statusRepo, webhookClient, cfg.Webhooks, logger)
+	taskService := service.NewTaskService(taskRepo, statusRepo, blobStore, cfg.Hierarchy, workflow)
+	statusService := service.NewStatusService(statusRepo)
+	labelService := service.NewLabelService(labelRepo)
+	userService := service.NewUserService(userRepo)
+	projectService := service.NewProjectService(projectRepo)
+	viewService := service.NewViewService(viewRepo)
+	commentService := service.NewCommentService(commentRepo, taskRepo)
+	attachmentService := service.NewAttachmentService(attachmentRepo, taskRepo, blobStore, cfg.Attachment)
+	reminderService := service.NewReminderService(reminderRepo, service.LogNotifier{Logger: logger}, cfg.Reminders)
+
+	// Setup the sinks task events are relayed to from the outbox
+	var sinks []service.EventSink
+	for _, name := range cfg.Outbox.Sinks {
+		switch name {
+		case "webhooks":
+			sinks = append(sinks, webhookService)
+		case "log":
+			sinks = append(sinks, service.LogSink{Logger: logger})
+		default:
+			logger.Error("Unknown outbox sink", "sink", name)
+			os.Exit(1)
+		}
+	}
+	outboxRelay := service.NewOutboxRelay(outboxRepo, sinks, cfg.Outbox)
+	eventStream := service.NewEventStream(outboxRepo, statusRepo, cfg.Events, logger)
+	hub := realtime.NewHub(cfg.Realtime, logger)
+
+	// Setup background jobs
+	jobs := scheduler.New(logger)
+	if cfg.Scheduler.Enabled {
+		jobs.Add("reminders", cfg.Reminders.Interval, func(ctx context.Context) error {
+			_, err := reminderService.Scan(ctx)
+			return err
+		})
+		jobs.Add("outbox", cfg.Outbox.Interval, func(ctx context.Context) error {
+			_, err := outboxRelay.Relay(ctx)
+			return err
+		})
+		jobs.Add("webhooks", cfg.Webhooks.Interval, func(ctx context.Context) error {
+			_, err := webhookService.DeliverDue(ctx)
+			return err
+		})
+		if cfg.Trash.Retention > 0 {
+			jobs.Add("trash", cfg.Trash.Interval, func(ctx context.Context) error {
+				_, err := taskService.PurgeTrash(ctx, cfg.Trash.Retention)
+				return err
+			})
+		}
+		if cfg.Archive.After > 0 {
+			jobs.Add("archive", cfg.Archive.Interval, func(ctx context.Context) error {
+				_, err := taskService.AutoArchive(ctx, cfg.Archive.After)
+				return err
+			})
+		}
+	}
 
 	// Setup HTTP handlers
 	taskHandler := httphandlers.NewTaskHandler(taskService, cfg.Pagination, logger)
+	taskHandler.AddSubresource(httphandlers.NewCommentHandler(commentService, cfg.Pagination, logger))
+	taskHandler.AddSubresource(httphandlers.NewAttachmentHandler(attachmentService, cfg.Attachment, logger))
+	statusHandler := httphandlers.NewStatusHandler(statusService, logger)
+	labelHandler := httphandlers.NewLabelHandler(labelService, logger)
+	userHandler := httphandlers.NewUserHandler(userService, logger)
+	projectHandler := httphandlers.NewProjectHandler(projectService, taskHandler, statusHandler, logger)
+	viewHandler := httphandlers.NewViewHandler(viewService, taskHandler, logger)
+	webhookHandler := httphandlers.NewWebhookHandler(webhookService, cfg.Pagination, logger)
+	eventHandler := httphandlers.NewEventHandler(eventStream, cfg.Events, logger)
+	realtimeHandler := httphandlers.NewRealtimeHandler(hub, cfg.Realtime, logger)
 
 	// Create router
 	r := chi.NewRouter()
@@ -67,8 +169,16 @@ func main() {
 		// Apply database connection middleware to API routes
 		r.Use(httphandlers.DBConnectionMiddleware(&isDBConnected))
 		
-		// Register task routes
+		// Register resource routes
 		taskHandler.RegisterRoutes(r)
+		statusHandler.RegisterRoutes(r)
+		labelHandler.RegisterRoutes(r)
+		userHandler.RegisterRoutes(r)
+		projectHandler.RegisterRoutes(r)
+		viewHandler.RegisterRoutes(r)
+		webhookHandler.RegisterRoutes(r)
+		eventHandler.RegisterRoutes(r)
+		realtimeHandler.RegisterRoutes(r)
 	})
 
 	// Serve OpenAPI UI and spec
@@ -98,6 +208,21 @@ func main() {
 		}
 	}()
 
+	// Start background jobs
+	jobs.Start(ctx)
+
+	// Follow task events for the live stream and the WebSocket hub; open
+	// streams and connections are ended as soon as shutdown begins, since
+	// they would never become idle
+	streamCtx, stopStreams := context.WithCancel(ctx)
+	defer stopStreams()
+	srv.RegisterOnShutdown(func() {
+		stopStreams()
+		hub.Close()
+	})
+	go eventStream.Run(streamCtx)
+	go hub.Follow(streamCtx, eventStream)
+
 	// Wait for shutdown signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -112,6 +237,11 @@ func main() {
 	// Set database connection to false
 	isDBConnected = false
 	
+	// Stop background jobs, letting running ones finish
+	if err := jobs.Stop(ctx); err != nil {
+		logger.Error("Background jobs did not stop in time", "error", err)
+	}
+
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Error("Server shutdown failed", "error", err)
 		os.Exit(1)
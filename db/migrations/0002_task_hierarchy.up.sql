@@ -0,0 +1,5 @@
+ALTER TABLE tasks ADD COLUMN parent_id UUID REFERENCES tasks(id) ON DELETE CASCADE;
+
+ALTER TABLE tasks ADD CONSTRAINT tasks_parent_not_self CHECK (parent_id IS NULL OR parent_id <> id);
+
+CREATE INDEX idx_tasks_parent_id ON tasks(parent_id);
@@ -0,0 +1,10 @@
+-- blocker_id must be finished before blocked_id can start
+CREATE TABLE task_dependencies (
+  blocker_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  blocked_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (blocker_id, blocked_id),
+  CONSTRAINT task_dependencies_not_self CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX idx_task_dependencies_blocked_id ON task_dependencies(blocked_id);
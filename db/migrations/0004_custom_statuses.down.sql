@@ -0,0 +1,20 @@
+CREATE TYPE task_status AS ENUM ('Pending','InProgress','Completed','Cancelled');
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_status_fkey;
+
+-- Statuses added since cannot be cast to the enum: move their tasks to the
+-- seeded status of the same category first
+UPDATE tasks t
+SET status = CASE s.category
+  WHEN 'todo' THEN 'Pending'
+  WHEN 'doing' THEN 'InProgress'
+  ELSE 'Completed'
+END
+FROM statuses s
+WHERE s.name = t.status
+  AND t.status NOT IN ('Pending', 'InProgress', 'Completed', 'Cancelled');
+
+ALTER TABLE tasks ALTER COLUMN status TYPE task_status USING status::task_status;
+ALTER TABLE tasks ALTER COLUMN status SET DEFAULT 'Pending';
+
+DROP TABLE IF EXISTS statuses;
@@ -0,0 +1,26 @@
+CREATE TABLE statuses (
+  id UUID PRIMARY KEY,
+  name VARCHAR(50) NOT NULL UNIQUE,
+  category VARCHAR(10) NOT NULL CHECK (category IN ('todo', 'doing', 'done')),
+  position INT NOT NULL DEFAULT 0,
+  color VARCHAR(7) NOT NULL DEFAULT '#9e9e9e',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_statuses_position ON statuses(position);
+
+INSERT INTO statuses (id, name, category, position, color) VALUES
+  (gen_random_uuid(), 'Pending', 'todo', 10, '#9e9e9e'),
+  (gen_random_uuid(), 'InProgress', 'doing', 20, '#1e88e5'),
+  (gen_random_uuid(), 'Completed', 'done', 30, '#43a047'),
+  (gen_random_uuid(), 'Cancelled', 'done', 40, '#e53935');
+
+-- Replace the fixed enum with a reference to the live status set.
+-- ON UPDATE CASCADE lets a status be renamed without touching task code.
+ALTER TABLE tasks ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE tasks ALTER COLUMN status TYPE VARCHAR(50) USING status::text;
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_fkey
+  FOREIGN KEY (status) REFERENCES statuses(name) ON UPDATE CASCADE;
+
+DROP TYPE task_status;
@@ -0,0 +1,16 @@
+CREATE TABLE labels (
+  id UUID PRIMARY KEY,
+  name VARCHAR(50) NOT NULL UNIQUE,
+  color VARCHAR(7) NOT NULL DEFAULT '#9e9e9e',
+  description TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE task_labels (
+  task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+  PRIMARY KEY (task_id, label_id)
+);
+
+CREATE INDEX idx_task_labels_label_id ON task_labels(label_id);
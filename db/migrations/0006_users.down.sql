@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS task_assignments;
+
+ALTER TABLE tasks
+  DROP COLUMN IF EXISTS reporter_id,
+  DROP COLUMN IF EXISTS assignee_id;
+
+DROP TABLE IF EXISTS users;
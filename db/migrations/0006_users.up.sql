@@ -0,0 +1,25 @@
+CREATE TABLE users (
+  id UUID PRIMARY KEY,
+  email VARCHAR(254) NOT NULL UNIQUE,
+  name VARCHAR(100) NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE tasks
+  ADD COLUMN assignee_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  ADD COLUMN reporter_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_assignee_id ON tasks(assignee_id);
+
+-- Every change of assignee, oldest first per task
+CREATE TABLE task_assignments (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  previous_assignee_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  assignee_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_task_assignments_task_id ON task_assignments(task_id, created_at);
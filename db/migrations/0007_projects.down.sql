@@ -0,0 +1,7 @@
+ALTER TABLE tasks
+  DROP CONSTRAINT IF EXISTS tasks_number_requires_project,
+  DROP CONSTRAINT IF EXISTS tasks_project_number_key,
+  DROP COLUMN IF EXISTS number,
+  DROP COLUMN IF EXISTS project_id;
+
+DROP TABLE IF EXISTS projects;
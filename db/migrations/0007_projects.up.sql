@@ -0,0 +1,18 @@
+CREATE TABLE projects (
+  id UUID PRIMARY KEY,
+  key VARCHAR(10) NOT NULL UNIQUE CHECK (key ~ '^[A-Z][A-Z0-9]{1,9}$'),
+  name VARCHAR(100) NOT NULL,
+  description TEXT,
+  archived BOOLEAN NOT NULL DEFAULT false,
+  -- Number handed to the next task created in the project
+  next_number INT NOT NULL DEFAULT 1,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Tasks created outside a project keep working without a key
+ALTER TABLE tasks
+  ADD COLUMN project_id UUID REFERENCES projects(id) ON DELETE RESTRICT,
+  ADD COLUMN number INT,
+  ADD CONSTRAINT tasks_project_number_key UNIQUE (project_id, number),
+  ADD CONSTRAINT tasks_number_requires_project CHECK ((project_id IS NULL) = (number IS NULL));
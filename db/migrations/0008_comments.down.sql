@@ -0,0 +1,10 @@
+CREATE OR REPLACE FUNCTION update_task_modified_column()
+RETURNS TRIGGER AS $$
+BEGIN
+   NEW.updated_at = now();
+   NEW.version = OLD.version + 1;
+   RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS comments;
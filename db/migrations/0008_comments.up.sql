@@ -0,0 +1,28 @@
+CREATE TABLE comments (
+  id UUID PRIMARY KEY,
+  task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  parent_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+  author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  body TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  -- Deleted comments stay behind as tombstones so that threads keep their shape
+  deleted_at TIMESTAMPTZ,
+  version INT NOT NULL DEFAULT 1
+);
+
+CREATE INDEX idx_comments_task_id ON comments(task_id, created_at, id);
+
+-- Activity on a task (such as a new comment) moves updated_at without
+-- counting as a new version. Callers opt in per transaction with
+-- set_config('task_svc.activity_only', 'on', true).
+CREATE OR REPLACE FUNCTION update_task_modified_column()
+RETURNS TRIGGER AS $$
+BEGIN
+   NEW.updated_at = now();
+   IF current_setting('task_svc.activity_only', true) IS DISTINCT FROM 'on' THEN
+      NEW.version = OLD.version + 1;
+   END IF;
+   RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
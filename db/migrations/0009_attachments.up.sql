@@ -0,0 +1,14 @@
+CREATE TABLE attachments (
+  id UUID PRIMARY KEY,
+  task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  filename VARCHAR(255) NOT NULL,
+  content_type VARCHAR(255) NOT NULL,
+  size BIGINT NOT NULL CHECK (size >= 0),
+  checksum_sha256 CHAR(64) NOT NULL,
+  -- Key of the content in the configured blob store
+  storage_key TEXT NOT NULL UNIQUE,
+  uploaded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_attachments_task_id ON attachments(task_id, created_at);
@@ -0,0 +1,5 @@
+ALTER TABLE tasks
+  ADD COLUMN priority VARCHAR(10) NOT NULL DEFAULT 'Medium'
+    CHECK (priority IN ('Urgent', 'High', 'Medium', 'Low'));
+
+CREATE INDEX idx_tasks_priority ON tasks(priority);
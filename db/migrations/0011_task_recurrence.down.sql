@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tasks_recurs_from_id;
+ALTER TABLE tasks
+  DROP COLUMN IF EXISTS recurs_from_id,
+  DROP COLUMN IF EXISTS recurrence;
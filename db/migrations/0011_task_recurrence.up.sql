@@ -0,0 +1,9 @@
+-- An RRULE (RFC 5545 subset) repeating the task. Completing an occurrence
+-- creates the next one, which points back at it through recurs_from_id.
+ALTER TABLE tasks
+  ADD COLUMN recurrence TEXT,
+  ADD COLUMN recurs_from_id UUID REFERENCES tasks(id) ON DELETE SET NULL;
+
+-- Each occurrence is followed by at most one next occurrence, even when it is
+-- completed more than once
+CREATE UNIQUE INDEX idx_tasks_recurs_from_id ON tasks(recurs_from_id);
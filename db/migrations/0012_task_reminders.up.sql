@@ -0,0 +1,11 @@
+-- One row per reminder fired. The key makes every threshold fire once per due
+-- date, so moving a task's due date arms its reminders again.
+CREATE TABLE task_reminders (
+  task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  threshold VARCHAR(20) NOT NULL,
+  due_date TIMESTAMPTZ NOT NULL,
+  fired_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (task_id, threshold, due_date)
+);
+
+CREATE INDEX idx_task_reminders_fired_at ON task_reminders(fired_at);
@@ -0,0 +1,18 @@
+-- Transactional outbox: task events are written in the same transaction as
+-- the change they describe and relayed to the event sinks in seq order. The
+-- id is the event's dedup ID and stays the same however often it is relayed.
+CREATE TABLE outbox_events (
+  seq BIGSERIAL PRIMARY KEY,
+  id UUID NOT NULL UNIQUE,
+  aggregate_id UUID NOT NULL,
+  event_type VARCHAR(50) NOT NULL,
+  payload JSONB NOT NULL,
+  occurred_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  published_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_outbox_events_unpublished ON outbox_events(seq) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_events_published_at ON outbox_events(published_at) WHERE published_at IS NOT NULL;
+
+-- A relayed event is queued at most once per webhook
+CREATE UNIQUE INDEX idx_webhook_deliveries_event ON webhook_deliveries(webhook_id, event_id);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS notify_task_event_trigger ON outbox_events;
+DROP FUNCTION IF EXISTS notify_task_event();
@@ -0,0 +1,15 @@
+-- Announce every outbox event on the task_events channel. Notifications are
+-- sent when the writing transaction commits, so listeners on every replica
+-- learn of committed events only.
+CREATE OR REPLACE FUNCTION notify_task_event()
+RETURNS TRIGGER AS $$
+BEGIN
+   PERFORM pg_notify('task_events', NEW.seq::text);
+   RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_task_event_trigger
+AFTER INSERT ON outbox_events
+FOR EACH ROW
+EXECUTE FUNCTION notify_task_event();
@@ -0,0 +1,39 @@
+-- Every version of a task: who changed it and when, the fields that changed
+-- and a snapshot of the task as it was at that version
+CREATE TABLE task_history (
+  task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  version INTEGER NOT NULL,
+  action VARCHAR(20) NOT NULL,
+  changed_by UUID,
+  changed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  changes JSONB NOT NULL DEFAULT '[]',
+  snapshot JSONB NOT NULL,
+  PRIMARY KEY (task_id, version)
+);
+
+-- Existing tasks start their history at their current version
+INSERT INTO task_history (task_id, version, action, changed_at, snapshot)
+SELECT t.id, t.version, 'imported', t.updated_at, jsonb_strip_nulls(jsonb_build_object(
+  'id', t.id,
+  'project_id', t.project_id,
+  'key', (SELECT p.key || '-' || t.number FROM projects p WHERE p.id = t.project_id),
+  'title', t.title,
+  'description', t.description,
+  'status', t.status,
+  'priority', t.priority,
+  'due_date', t.due_date,
+  'parent_id', t.parent_id,
+  'assignee_id', t.assignee_id,
+  'reporter_id', t.reporter_id,
+  'labels', COALESCE((
+    SELECT to_jsonb(array_agg(l.name ORDER BY l.name))
+    FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+    WHERE tl.task_id = t.id
+  ), '[]'::jsonb),
+  'recurrence', t.recurrence,
+  'recurs_from_id', t.recurs_from_id,
+  'created_at', t.created_at,
+  'updated_at', t.updated_at,
+  'version', t.version
+))
+FROM tasks t;
@@ -0,0 +1,2 @@
+ALTER TABLE task_history
+  DROP COLUMN IF EXISTS restored_version;
@@ -0,0 +1,3 @@
+-- A version produced by reverting a task names the version it restored
+ALTER TABLE task_history
+  ADD COLUMN restored_version INTEGER;
@@ -0,0 +1,5 @@
+-- Deleted tasks are kept as tombstones until the retention job purges them
+ALTER TABLE tasks
+  ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_tasks_deleted_at ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_tasks_archived_at;
+DROP INDEX IF EXISTS idx_tasks_completed_at;
+
+DROP TRIGGER IF EXISTS set_task_completed_trigger ON tasks;
+DROP FUNCTION IF EXISTS set_task_completed_at();
+
+ALTER TABLE tasks
+  DROP COLUMN IF EXISTS archived_at,
+  DROP COLUMN IF EXISTS completed_at;
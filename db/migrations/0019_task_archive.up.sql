@@ -0,0 +1,41 @@
+-- completed_at records when a task last entered a done-category status; it
+-- is kept by the schema so that archiving can select tasks by it
+ALTER TABLE tasks
+  ADD COLUMN completed_at TIMESTAMPTZ,
+  ADD COLUMN archived_at TIMESTAMPTZ;
+
+CREATE OR REPLACE FUNCTION set_task_completed_at()
+RETURNS TRIGGER AS $$
+DECLARE
+   now_done BOOLEAN;
+   was_done BOOLEAN := false;
+BEGIN
+   SELECT category = 'done' INTO now_done FROM statuses WHERE name = NEW.status;
+   IF TG_OP = 'UPDATE' THEN
+      SELECT category = 'done' INTO was_done FROM statuses WHERE name = OLD.status;
+   END IF;
+
+   IF NOT COALESCE(now_done, false) THEN
+      NEW.completed_at = NULL;
+   ELSIF NOT COALESCE(was_done, false) THEN
+      NEW.completed_at = now();
+   END IF;
+   RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER set_task_completed_trigger
+BEFORE INSERT OR UPDATE OF status ON tasks
+FOR EACH ROW
+EXECUTE FUNCTION set_task_completed_at();
+
+-- Tasks already done were last modified no earlier than they were completed;
+-- the backfill must not count as a new version
+ALTER TABLE tasks DISABLE TRIGGER update_task_modified_trigger;
+UPDATE tasks t SET completed_at = t.updated_at
+FROM statuses s
+WHERE s.name = t.status AND s.category = 'done';
+ALTER TABLE tasks ENABLE TRIGGER update_task_modified_trigger;
+
+CREATE INDEX idx_tasks_completed_at ON tasks(completed_at) WHERE archived_at IS NULL;
+CREATE INDEX idx_tasks_archived_at ON tasks(archived_at) WHERE archived_at IS NOT NULL;
@@ -0,0 +1,9 @@
+-- Full-text search over titles and descriptions. The vector is a generated
+-- column, so every write to a task keeps it in sync; title words weigh more.
+ALTER TABLE tasks
+  ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B')
+  ) STORED;
+
+CREATE INDEX idx_tasks_search_vector ON tasks USING GIN (search_vector);
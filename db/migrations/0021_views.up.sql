@@ -0,0 +1,17 @@
+-- Saved task lists. filter holds the task list query parameters that select
+-- the view's tasks; sort and columns say how to show them. Private views are
+-- visible to their owner only, shared views to everyone.
+CREATE TABLE views (
+  id UUID PRIMARY KEY,
+  owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  filter JSONB NOT NULL DEFAULT '{}',
+  sort TEXT NOT NULL DEFAULT '',
+  columns TEXT[] NOT NULL DEFAULT '{}',
+  shared BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT views_owner_name_key UNIQUE (owner_id, name)
+);
+
+CREATE INDEX idx_views_shared ON views(name) WHERE shared;
@@ -0,0 +1,17 @@
+-- Move tasks off project statuses onto the first default status of the same
+-- category, or the first todo status when the default set has none
+UPDATE tasks t
+SET status = COALESCE(
+  (SELECT d.name FROM statuses d
+   WHERE d.project_id IS NULL AND d.category = s.category
+   ORDER BY d.position, d.name LIMIT 1),
+  (SELECT d.name FROM statuses d
+   WHERE d.project_id IS NULL AND d.category = 'todo'
+   ORDER BY d.position, d.name LIMIT 1))
+FROM statuses s
+WHERE s.name = t.status AND s.project_id IS NOT NULL;
+
+DELETE FROM statuses WHERE project_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_statuses_project_id;
+ALTER TABLE statuses DROP COLUMN IF EXISTS project_id;
@@ -0,0 +1,7 @@
+-- Statuses without a project form the default set every task can use; a
+-- project adds statuses of its own on top of it. Names stay unique across
+-- projects because tasks refer to their status by name.
+ALTER TABLE statuses
+  ADD COLUMN project_id UUID REFERENCES projects(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_statuses_project_id ON statuses(project_id);
@@ -0,0 +1,6 @@
+-- Severity is optional: only tasks tracking a problem carry one
+ALTER TABLE tasks
+  ADD COLUMN severity VARCHAR(10)
+    CHECK (severity IN ('Critical', 'Major', 'Minor', 'Trivial'));
+
+CREATE INDEX idx_tasks_severity ON tasks(severity);
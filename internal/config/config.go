@@ -9,10 +9,22 @@ import (
 
 // Config represents the application configuration
 type Config struct {
-	App        AppConfig        `envPrefix:"APP_"`
-	HTTP       HTTPConfig       `envPrefix:"HTTP_"`
-	DB         DBConfig         `envPrefix:"DB_"`
-	Pagination PaginationConfig `envPrefix:"PAGINATION_"`
+	App        AppConfig         `envPrefix:"APP_"`
+	HTTP       HTTPConfig        `envPrefix:"HTTP_"`
+	DB         DBConfig          `envPrefix:"DB_"`
+	Pagination PaginationConfig  `envPrefix:"PAGINATION_"`
+	Hierarchy  HierarchyConfig   `envPrefix:"HIERARCHY_"`
+	Workflow   WorkflowConfig    `envPrefix:"WORKFLOW_"`
+	Storage    StorageConfig     `envPrefix:"STORAGE_"`
+	Attachment AttachmentConfig  `envPrefix:"ATTACHMENTS_"`
+	Scheduler  SchedulerConfig   `envPrefix:"SCHEDULER_"`
+	Reminders  ReminderConfig    `envPrefix:"REMINDERS_"`
+	Webhooks   WebhookConfig     `envPrefix:"WEBHOOKS_"`
+	Outbox     OutboxConfig      `envPrefix:"OUTBOX_"`
+	Events     EventStreamConfig `envPrefix:"EVENTS_"`
+	Realtime   RealtimeConfig    `envPrefix:"WS_"`
+	Trash      TrashConfig       `envPrefix:"TRASH_"`
+	Archive    ArchiveConfig     `envPrefix:"ARCHIVE_"`
 }
 
 // AppConfig contains general application settings
@@ -40,6 +52,130 @@ type DBConfig struct {
 type PaginationConfig struct {
 	DefaultSize int `env:"DEFAULT_SIZE" envDefault:"20"`
 	MaxSize     int `env:"MAX_SIZE" envDefault:"100"`
+	// CursorSecret signs task list cursors; every replica needs the same one.
+	// When empty, a random secret is used and cursors do not outlive the process.
+	CursorSecret string `env:"CURSOR_SECRET"`
+}
+
+// HierarchyConfig contains parent/child task settings
+type HierarchyConfig struct {
+	// RollupStatus moves a parent forward automatically when its children progress
+	RollupStatus bool `env:"ROLLUP_STATUS" envDefault:"false"`
+}
+
+// WorkflowConfig contains the task status workflow
+type WorkflowConfig struct {
+	// Initial lists the statuses a task may be created in
+	Initial []string `env:"INITIAL" envDefault:"Pending,InProgress"`
+	// Transitions maps each status to the statuses it may move to, separated by "|".
+	// A status that is mentioned without an entry of its own is terminal; statuses
+	// not mentioned anywhere are left unconstrained.
+	Transitions map[string]string `env:"TRANSITIONS" envDefault:"Pending:InProgress|Completed|Cancelled,InProgress:Pending|Completed|Cancelled,Completed:InProgress,Cancelled:Pending"`
+}
+
+// StorageConfig selects and configures the blob store that holds attachment contents
+type StorageConfig struct {
+	Driver   string   `env:"DRIVER" envDefault:"local"` // local or s3
+	LocalDir string   `env:"LOCAL_DIR" envDefault:"data/attachments"`
+	S3       S3Config `envPrefix:"S3_"`
+}
+
+// S3Config contains the settings of an S3-compatible object store
+type S3Config struct {
+	Endpoint  string `env:"ENDPOINT" envDefault:"http://localhost:9000"`
+	Region    string `env:"REGION" envDefault:"us-east-1"`
+	Bucket    string `env:"BUCKET" envDefault:"task-attachments"`
+	AccessKey string `env:"ACCESS_KEY"`
+	SecretKey string `env:"SECRET_KEY"`
+	// PathStyle addresses the bucket in the path rather than the host name, as
+	// MinIO and most self-hosted stores expect
+	PathStyle bool `env:"PATH_STYLE" envDefault:"true"`
+}
+
+// AttachmentConfig contains limits applied to uploaded attachments
+type AttachmentConfig struct {
+	MaxSize int64 `env:"MAX_SIZE" envDefault:"10485760"` // bytes
+	// AllowedTypes lists the accepted media types; "image/*" accepts every subtype
+	AllowedTypes []string `env:"ALLOWED_TYPES" envDefault:"image/*,text/plain,application/json,application/pdf,application/zip,application/gzip"`
+}
+
+// SchedulerConfig contains settings shared by the background jobs
+type SchedulerConfig struct {
+	// Enabled runs the background jobs in this process; replicas coordinate
+	// through the database, so it is safe to leave on everywhere
+	Enabled bool `env:"ENABLED" envDefault:"true"`
+}
+
+// ReminderConfig contains the due date reminder settings
+type ReminderConfig struct {
+	Interval time.Duration `env:"INTERVAL" envDefault:"1m"`
+	// Leads lists how long before the due date a reminder fires. An overdue
+	// reminder always fires once the due date has passed.
+	Leads     []time.Duration `env:"LEADS" envDefault:"24h,1h"`
+	BatchSize int             `env:"BATCH_SIZE" envDefault:"500"`
+}
+
+// WebhookConfig contains the webhook delivery settings
+type WebhookConfig struct {
+	// Interval is how often the delivery queue is polled
+	Interval  time.Duration `env:"INTERVAL" envDefault:"5s"`
+	Timeout   time.Duration `env:"TIMEOUT" envDefault:"10s"`
+	BatchSize int           `env:"BATCH_SIZE" envDefault:"20"`
+	// MaxAttempts is the number of attempts after which a delivery is dead
+	MaxAttempts int `env:"MAX_ATTEMPTS" envDefault:"8"`
+	// Failed attempts are retried after BackoffBase, doubling up to BackoffMax
+	BackoffBase time.Duration `env:"BACKOFF_BASE" envDefault:"30s"`
+	BackoffMax  time.Duration `env:"BACKOFF_MAX" envDefault:"1h"`
+}
+
+// OutboxConfig contains the event outbox relay settings
+type OutboxConfig struct {
+	// Interval is how often the outbox is polled for unpublished events
+	Interval  time.Duration `env:"INTERVAL" envDefault:"1s"`
+	BatchSize int           `env:"BATCH_SIZE" envDefault:"100"`
+	// Sinks lists the sinks events are relayed to: webhooks, log
+	Sinks []string `env:"SINKS" envDefault:"webhooks"`
+	// Retention is how long published events are kept; 0 keeps them forever
+	Retention time.Duration `env:"RETENTION" envDefault:"168h"`
+}
+
+// EventStreamConfig contains the live event stream settings
+type EventStreamConfig struct {
+	// BufferSize is the number of recent events kept for clients resuming a stream
+	BufferSize int `env:"BUFFER_SIZE" envDefault:"1000"`
+	// ClientBuffer is the number of events queued for a client before it is
+	// considered too slow and disconnected
+	ClientBuffer int           `env:"CLIENT_BUFFER" envDefault:"256"`
+	Heartbeat    time.Duration `env:"HEARTBEAT" envDefault:"15s"`
+}
+
+// RealtimeConfig contains the WebSocket settings
+type RealtimeConfig struct {
+	// QueueSize is the number of messages queued for a connection before it
+	// is considered too slow and closed
+	QueueSize int `env:"QUEUE_SIZE" envDefault:"256"`
+	// Heartbeat is how often connections are pinged; one that stays silent
+	// for two heartbeats is closed
+	Heartbeat        time.Duration `env:"HEARTBEAT" envDefault:"30s"`
+	MaxMessageSize   int           `env:"MAX_MESSAGE_SIZE" envDefault:"65536"`
+	MaxSubscriptions int           `env:"MAX_SUBSCRIPTIONS" envDefault:"100"`
+}
+
+// TrashConfig contains the settings for deleted tasks
+type TrashConfig struct {
+	// Interval is how often expired tasks are purged from the trash
+	Interval time.Duration `env:"INTERVAL" envDefault:"1h"`
+	// Retention is how long deleted tasks stay restorable; 0 keeps them forever
+	Retention time.Duration `env:"RETENTION" envDefault:"720h"`
+}
+
+// ArchiveConfig contains the auto-archiving policy for finished tasks
+type ArchiveConfig struct {
+	// Interval is how often finished tasks are checked for auto-archiving
+	Interval time.Duration `env:"INTERVAL" envDefault:"1h"`
+	// After is how long a task stays in a done-category status before it is
+	// archived automatically; 0 disables auto-archiving
+	After time.Duration `env:"AFTER" envDefault:"0"`
 }
 
 // Load loads configuration from environment variables
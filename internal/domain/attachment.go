@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attachment describes a file attached to a task. The content itself lives in
+// the blob store under StorageKey.
+type Attachment struct {
+	ID             uuid.UUID  `json:"id"`
+	TaskID         uuid.UUID  `json:"task_id"`
+	Filename       string     `json:"filename"`
+	ContentType    string     `json:"content_type"`
+	Size           int64      `json:"size"`
+	ChecksumSHA256 string     `json:"checksum_sha256"`
+	StorageKey     string     `json:"-"`
+	UploadedBy     *uuid.UUID `json:"uploaded_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// UploadAttachmentRequest carries an uploaded file. ContentType is the type
+// declared by the client and may be empty.
+type UploadAttachmentRequest struct {
+	Filename    string
+	ContentType string
+	Content     io.Reader
+}
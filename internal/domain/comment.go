@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Comment is a message in the discussion of a task. Replies point at the
+// comment they answer through ParentID.
+type Comment struct {
+	ID        uuid.UUID  `json:"id"`
+	TaskID    uuid.UUID  `json:"task_id"`
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
+	AuthorID  *uuid.UUID `json:"author_id,omitempty"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	Version   int        `json:"version"`
+}
+
+// CreateCommentRequest represents the payload for adding a comment to a task
+type CreateCommentRequest struct {
+	Body     string     `json:"body"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+// UpdateCommentRequest represents the payload for editing a comment
+type UpdateCommentRequest struct {
+	Body    string `json:"body"`
+	Version int    `json:"version"`
+}
+
+// CommentCursor marks the last comment of a page in (CreatedAt, ID) order
+type CommentCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// CommentFilter selects a page of a task's comments, oldest first
+type CommentFilter struct {
+	After *CommentCursor
+	Limit int
+}
+
+// CommentList represents a page of comments. Next is set when more comments follow.
+type CommentList struct {
+	Items []Comment
+	Next  *CommentCursor
+}
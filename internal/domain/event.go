@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskEventType names a change in the lifecycle of a task
+type TaskEventType string
+
+// Task event types
+const (
+	EventTaskCreated       TaskEventType = "task.created"
+	EventTaskUpdated       TaskEventType = "task.updated"
+	EventTaskStatusChanged TaskEventType = "task.status_changed"
+	EventTaskDeleted       TaskEventType = "task.deleted"
+	EventTaskRestored      TaskEventType = "task.restored"
+	EventTaskArchived      TaskEventType = "task.archived"
+	EventTaskUnarchived    TaskEventType = "task.unarchived"
+	EventTaskReminder      TaskEventType = "task.reminder"
+	EventTaskOverdue       TaskEventType = "task.overdue"
+	// EventTaskCompleted is not recorded itself; it is derived for webhooks
+	// from a status change into the done category
+	EventTaskCompleted TaskEventType = "task.completed"
+)
+
+// TaskEventTypes lists every task event type
+var TaskEventTypes = []TaskEventType{EventTaskCreated, EventTaskUpdated, EventTaskStatusChanged, EventTaskDeleted, EventTaskRestored, EventTaskArchived, EventTaskUnarchived, EventTaskReminder, EventTaskOverdue, EventTaskCompleted}
+
+// Valid reports whether t is a known event type
+func (t TaskEventType) Valid() bool {
+	for _, known := range TaskEventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskEvent records a change to a task together with the task as it was
+// right after the change; a deleted task is sent as it was moved to the
+// trash. The ID stays the same however often the event is delivered, so that
+// consumers can drop duplicates.
+type TaskEvent struct {
+	ID         uuid.UUID     `json:"id"`
+	Type       TaskEventType `json:"type"`
+	OccurredAt time.Time     `json:"occurred_at"`
+	Task       Task          `json:"task"`
+	// PreviousStatus is set on status changes
+	PreviousStatus *Status `json:"previous_status,omitempty"`
+	// Threshold names the reminder that fired on task.reminder and task.overdue
+	Threshold string `json:"threshold,omitempty"`
+	// Seq is the event's position in the outbox, set when it is read back
+	Seq int64 `json:"-"`
+}
+
+// EventFilter selects task events by the status and project of their task.
+// Empty fields match every event.
+type EventFilter struct {
+	Statuses  []Status
+	ProjectID *uuid.UUID
+}
+
+// Matches reports whether event concerns a task in the filter's project that
+// is in one of its statuses, or has just left one of them
+func (f EventFilter) Matches(event TaskEvent) bool {
+	if f.ProjectID != nil && (event.Task.ProjectID == nil || *event.Task.ProjectID != *f.ProjectID) {
+		return false
+	}
+	if len(f.Statuses) == 0 {
+		return true
+	}
+	for _, status := range f.Statuses {
+		if event.Task.Status == status || (event.PreviousStatus != nil && *event.PreviousStatus == status) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewTaskEvent creates an event of the given type for task
+func NewTaskEvent(eventType TaskEventType, task Task) TaskEvent {
+	return TaskEvent{
+		ID:         uuid.New(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Task:       task,
+	}
+}
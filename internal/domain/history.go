@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HistoryAction names what produced a version of a task
+type HistoryAction string
+
+// History actions
+const (
+	HistoryCreated HistoryAction = "created"
+	HistoryUpdated HistoryAction = "updated"
+	// HistoryReverted marks a version that restored the fields of an earlier one
+	HistoryReverted   HistoryAction = "reverted"
+	HistoryDeleted    HistoryAction = "deleted"
+	HistoryRestored   HistoryAction = "restored"
+	HistoryArchived   HistoryAction = "archived"
+	HistoryUnarchived HistoryAction = "unarchived"
+	// HistoryImported marks the first recorded version of a task that existed
+	// before its history was kept
+	HistoryImported HistoryAction = "imported"
+)
+
+// FieldChange records the previous and new value of one task field, in the
+// field's JSON form
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// TaskHistoryEntry records one version of a task: who produced it and when,
+// and the fields that changed from the version before. A nil ChangedBy means
+// the caller was anonymous. RestoredVersion is set on reverts.
+type TaskHistoryEntry struct {
+	TaskID          uuid.UUID     `json:"task_id"`
+	Version         int           `json:"version"`
+	Action          HistoryAction `json:"action"`
+	RestoredVersion *int          `json:"restored_version,omitempty"`
+	ChangedBy       *uuid.UUID    `json:"changed_by,omitempty"`
+	ChangedAt       time.Time     `json:"changed_at"`
+	Changes         []FieldChange `json:"changes"`
+}
+
+// RevertTaskRequest represents the payload for reverting a task to an
+// earlier version. Version is the task's current version, for optimistic
+// locking.
+type RevertTaskRequest struct {
+	ToVersion int `json:"to_version"`
+	Version   int `json:"version"`
+}
+
+// DiffTasks lists the fields a user can change that differ between two
+// versions of a task, in the order they appear in Task
+func DiffTasks(before, after Task) []FieldChange {
+	changes := []FieldChange{}
+	add := func(field string, from, to any, equal bool) {
+		if !equal {
+			changes = append(changes, FieldChange{Field: field, From: from, To: to})
+		}
+	}
+
+	add("project_id", before.ProjectID, after.ProjectID, equalPtr(before.ProjectID, after.ProjectID))
+	add("title", before.Title, after.Title, before.Title == after.Title)
+	add("description", before.Description, after.Description, equalPtr(before.Description, after.Description))
+	add("status", before.Status, after.Status, before.Status == after.Status)
+	add("priority", before.Priority, after.Priority, before.Priority == after.Priority)
+	add("severity", before.Severity, after.Severity, equalPtr(before.Severity, after.Severity))
+	add("due_date", before.DueDate, after.DueDate, equalTime(before.DueDate, after.DueDate))
+	add("parent_id", before.ParentID, after.ParentID, equalPtr(before.ParentID, after.ParentID))
+	add("assignee_id", before.AssigneeID, after.AssigneeID, equalPtr(before.AssigneeID, after.AssigneeID))
+	add("reporter_id", before.ReporterID, after.ReporterID, equalPtr(before.ReporterID, after.ReporterID))
+	add("labels", before.Labels, after.Labels, slices.Equal(before.Labels, after.Labels))
+	add("recurrence", before.Recurrence, after.Recurrence, equalPtr(before.Recurrence, after.Recurrence))
+
+	return changes
+}
+
+// equalPtr reports whether two optional values are equal
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// equalTime reports whether two optional instants are equal
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
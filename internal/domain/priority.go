@@ -0,0 +1,39 @@
+package domain
+
+// Priority ranks how urgently a task needs attention
+type Priority string
+
+// Priority constants
+const (
+	PriorityUrgent Priority = "Urgent"
+	PriorityHigh   Priority = "High"
+	PriorityMedium Priority = "Medium"
+	PriorityLow    Priority = "Low"
+)
+
+// DefaultPriority is given to tasks created without a priority
+const DefaultPriority = PriorityMedium
+
+// Priorities lists the priorities from most to least urgent
+var Priorities = []Priority{PriorityUrgent, PriorityHigh, PriorityMedium, PriorityLow}
+
+// Valid reports whether p is one of the known priorities
+func (p Priority) Valid() bool {
+	for _, known := range Priorities {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Rank numbers a priority from 1 (most urgent) upwards, in the order of
+// Priorities; unknown priorities rank 0
+func (p Priority) Rank() int {
+	for i, known := range Priorities {
+		if p == known {
+			return i + 1
+		}
+	}
+	return 0
+}
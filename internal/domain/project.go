@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Project groups tasks under a short key that prefixes their human-friendly keys
+type Project struct {
+	ID          uuid.UUID `json:"id"`
+	Key         string    `json:"key"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	Archived    bool      `json:"archived"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateProjectRequest represents the payload for creating a project
+type CreateProjectRequest struct {
+	Key         string  `json:"key"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
+// PatchProjectRequest represents the payload for partially updating a project.
+// The key is fixed once a project is created so that task keys stay stable.
+type PatchProjectRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Archived    *bool   `json:"archived,omitempty"`
+}
+
+// FormatTaskKey builds the human-friendly key of a task, such as BILL-123
+func FormatTaskKey(projectKey string, number int) string {
+	return projectKey + "-" + strconv.Itoa(number)
+}
+
+// ParseTaskKey splits a task key such as BILL-123 into its project key and number
+func ParseTaskKey(key string) (projectKey string, number int, ok bool) {
+	i := strings.LastIndex(key, "-")
+	if i <= 0 {
+		return "", 0, false
+	}
+	number, err := strconv.Atoi(key[i+1:])
+	if err != nil || number < 1 {
+		return "", 0, false
+	}
+	return strings.ToUpper(key[:i]), number, true
+}
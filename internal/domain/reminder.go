@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ThresholdOverdue names the reminder fired once a task's due date has passed
+const ThresholdOverdue = "overdue"
+
+// ReminderThreshold is a point relative to a task's due date at which a
+// reminder fires. A zero Lead fires once the task is overdue.
+type ReminderThreshold struct {
+	Name string
+	Lead time.Duration
+}
+
+// Reminder is a reminder fired for a task that is about to be due or overdue
+type Reminder struct {
+	TaskID     uuid.UUID  `json:"task_id"`
+	Title      string     `json:"title"`
+	AssigneeID *uuid.UUID `json:"assignee_id,omitempty"`
+	Threshold  string     `json:"threshold"`
+	DueDate    time.Time  `json:"due_date"`
+	FiredAt    time.Time  `json:"fired_at"`
+}
+
+// EventType returns the task event recorded when the reminder fires:
+// task.overdue for the overdue threshold and task.reminder for the others
+func (r Reminder) EventType() TaskEventType {
+	if r.Threshold == ThresholdOverdue {
+		return EventTaskOverdue
+	}
+	return EventTaskReminder
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SearchQuery is a parsed full-text search over task titles and
+// descriptions: a task matches when it matches every term of any group
+type SearchQuery struct {
+	Groups [][]SearchTerm
+}
+
+// SearchTerm matches one word, or several in a row for a phrase. With Prefix
+// the last word also matches the words it starts; Negated inverts the match.
+type SearchTerm struct {
+	Words   []string
+	Prefix  bool
+	Negated bool
+}
+
+// ParseSearchQuery parses a search in the syntax most search boxes accept:
+// words must all appear, "quoted words" must appear in a row, a trailing *
+// matches any word with that prefix, a leading - excludes a word or phrase
+// and OR separates alternatives. Words are split on anything but letters and
+// digits, so "e-mail" is the phrase "e mail". ok is false when the search
+// contains no words.
+func ParseSearchQuery(q string) (query SearchQuery, ok bool) {
+	group := []SearchTerm{}
+	runes := []rune(q)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		negated := false
+		if runes[i] == '-' {
+			negated = true
+			i++
+		}
+
+		var token string
+		quoted := i < len(runes) && runes[i] == '"'
+		if quoted {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			token = string(runes[i+1 : end])
+			i = end + 1
+		} else {
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '"' {
+				end++
+			}
+			token = string(runes[i:end])
+			i = end
+		}
+
+		if !quoted && !negated && token == "OR" {
+			if len(group) > 0 {
+				query.Groups = append(query.Groups, group)
+				group = []SearchTerm{}
+			}
+			continue
+		}
+
+		words := searchWords(token)
+		if len(words) == 0 {
+			continue
+		}
+		prefix := strings.HasSuffix(strings.TrimRightFunc(token, unicode.IsSpace), "*")
+		group = append(group, SearchTerm{Words: words, Prefix: prefix, Negated: negated})
+	}
+	if len(group) > 0 {
+		query.Groups = append(query.Groups, group)
+	}
+
+	return query, len(query.Groups) > 0
+}
+
+// searchWords splits text into lower-cased words of letters and digits
+func searchWords(text string) []string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return words
+}
+
+// TaskSearchHit is a task matched by a search, with its relevance and the
+// matching words of its title and description highlighted
+type TaskSearchHit struct {
+	Task           Task    `json:"task"`
+	Rank           float64 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        *string `json:"snippet,omitempty"`
+}
+
+// TaskSearchResults represents a paginated list of search hits, most relevant first
+type TaskSearchResults struct {
+	Items []TaskSearchHit `json:"items"`
+	Meta  PageMeta        `json:"meta"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+// Severity ranks how much harm the problem a task tracks does. Unlike
+// priority it is optional: tasks that are not about a problem have none.
+type Severity string
+
+// Severity constants
+const (
+	SeverityCritical Severity = "Critical"
+	SeverityMajor    Severity = "Major"
+	SeverityMinor    Severity = "Minor"
+	SeverityTrivial  Severity = "Trivial"
+)
+
+// Severities lists the severities from most to least severe
+var Severities = []Severity{SeverityCritical, SeverityMajor, SeverityMinor, SeverityTrivial}
+
+// Valid reports whether s is one of the known severities
+func (s Severity) Valid() bool {
+	for _, known := range Severities {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Rank numbers a severity from 1 (most severe) upwards, in the order of
+// Severities; unknown severities rank 0
+func (s Severity) Rank() int {
+	for i, known := range Severities {
+		if s == known {
+			return i + 1
+		}
+	}
+	return 0
+}
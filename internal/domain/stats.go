@@ -0,0 +1,83 @@
+package domain
+
+import "time"
+
+// StatsDimension names a way of grouping tasks when counting them
+type StatsDimension string
+
+// Stats dimensions. Weeks start on Monday, in UTC, and are named by that
+// Monday's date; a task counts once under each of its labels.
+const (
+	StatsByStatus        StatsDimension = "status"
+	StatsByPriority      StatsDimension = "priority"
+	StatsBySeverity      StatsDimension = "severity"
+	StatsByAssignee      StatsDimension = "assignee"
+	StatsByLabel         StatsDimension = "label"
+	StatsByDueBucket     StatsDimension = "due_bucket"
+	StatsByCreatedWeek   StatsDimension = "created_week"
+	StatsByCompletedWeek StatsDimension = "completed_week"
+)
+
+// StatsDimensions lists the dimensions tasks can be grouped by
+var StatsDimensions = []StatsDimension{
+	StatsByStatus,
+	StatsByPriority,
+	StatsBySeverity,
+	StatsByAssignee,
+	StatsByLabel,
+	StatsByDueBucket,
+	StatsByCreatedWeek,
+	StatsByCompletedWeek,
+}
+
+// Valid reports whether d is one of the known dimensions
+func (d StatsDimension) Valid() bool {
+	for _, known := range StatsDimensions {
+		if d == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DueBucket places a due date relative to the time stats are taken
+type DueBucket string
+
+// Due buckets, in the order they are listed
+const (
+	DueOverdue   DueBucket = "overdue"
+	DueWithin1d  DueBucket = "within_1d"
+	DueWithin7d  DueBucket = "within_7d"
+	DueWithin30d DueBucket = "within_30d"
+	DueLater     DueBucket = "later"
+	DueNone      DueBucket = "none"
+)
+
+// DueBuckets lists the due buckets from the most to the least pressing
+var DueBuckets = []DueBucket{DueOverdue, DueWithin1d, DueWithin7d, DueWithin30d, DueLater, DueNone}
+
+// TaskCounts counts the tasks of a group: all of them, those in a done
+// category status and those still open past their due date
+type TaskCounts struct {
+	Tasks   int `json:"tasks"`
+	Done    int `json:"done"`
+	Overdue int `json:"overdue"`
+}
+
+// TaskStatsGroup holds the counts of the tasks sharing a value of every
+// grouped dimension. A nil key means the tasks have no value, such as no
+// severity, no assignee, no label or not completed.
+type TaskStatsGroup struct {
+	Keys []*string `json:"keys"`
+	TaskCounts
+}
+
+// TaskStats holds task counts grouped by GroupBy, with the keys of each group
+// in the same order, and the counts of all matching tasks in Total. AsOf is
+// the time overdue tasks and due buckets were judged by.
+type TaskStats struct {
+	GroupBy []StatsDimension `json:"group_by"`
+	Groups  []TaskStatsGroup `json:"groups"`
+	Total   TaskCounts       `json:"total"`
+	AsOf    time.Time        `json:"as_of"`
+}
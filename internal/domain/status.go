@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatusCategory groups statuses by how far along the work is
+type StatusCategory string
+
+// StatusCategory constants
+const (
+	CategoryTodo  StatusCategory = "todo"
+	CategoryDoing StatusCategory = "doing"
+	CategoryDone  StatusCategory = "done"
+)
+
+// StatusDefinition describes one entry of the configurable status set. A
+// status without a ProjectID belongs to the default set that every task can
+// use; one with a ProjectID is only available to that project's tasks.
+type StatusDefinition struct {
+	ID        uuid.UUID      `json:"id"`
+	ProjectID *uuid.UUID     `json:"project_id,omitempty"`
+	Name      Status         `json:"name"`
+	Category  StatusCategory `json:"category"`
+	Position  int            `json:"position"`
+	Color     string         `json:"color"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// CreateStatusRequest represents the payload for creating a status. Without
+// a ProjectID the status joins the default set.
+type CreateStatusRequest struct {
+	ProjectID *uuid.UUID     `json:"project_id,omitempty"`
+	Name      Status         `json:"name"`
+	Category  StatusCategory `json:"category"`
+	Position  *int           `json:"position,omitempty"`
+	Color     *string        `json:"color,omitempty"`
+}
+
+// PatchStatusRequest represents the payload for partially updating a status
+type PatchStatusRequest struct {
+	Name     *Status         `json:"name,omitempty"`
+	Category *StatusCategory `json:"category,omitempty"`
+	Position *int            `json:"position,omitempty"`
+	Color    *string         `json:"color,omitempty"`
+}
+
+// StatusSet is a snapshot of the live statuses, ordered by position
+type StatusSet []StatusDefinition
+
+// ForProject returns the statuses available to the tasks of a project: the
+// default set and the project's own statuses. Tasks outside a project only
+// get the default set.
+func (s StatusSet) ForProject(projectID *uuid.UUID) StatusSet {
+	set := StatusSet{}
+	for _, def := range s {
+		if def.ProjectID == nil || (projectID != nil && *def.ProjectID == *projectID) {
+			set = append(set, def)
+		}
+	}
+	return set
+}
+
+// Lookup returns the definition of the named status
+func (s StatusSet) Lookup(name Status) (StatusDefinition, bool) {
+	for _, def := range s {
+		if def.Name == name {
+			return def, true
+		}
+	}
+	return StatusDefinition{}, false
+}
+
+// First returns the lowest-positioned status in the given category
+func (s StatusSet) First(category StatusCategory) (StatusDefinition, bool) {
+	for _, def := range s {
+		if def.Category == category {
+			return def, true
+		}
+	}
+	return StatusDefinition{}, false
+}
+
+// Category returns the category of the named status, or an empty category if it is unknown
+func (s StatusSet) Category(name Status) StatusCategory {
+	def, _ := s.Lookup(name)
+	return def.Category
+}
@@ -4,9 +4,12 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"task-svc/pkg/filter"
 )
 
-// Status represents the current state of a task
+// Status represents the current state of a task. Valid values are the names
+// in the live status set; the constants below are the statuses seeded by default.
 type Status string
 
 // Status constants
@@ -17,62 +20,265 @@ const (
 	StatusCancelled  Status = "Cancelled"
 )
 
-// Task represents a task in the system
+// Task represents a task in the system. A recurring task carries an RRULE in
+// Recurrence; RecursFromID links each occurrence to the one before it.
+// CompletedAt is when the task last entered a done-category status, ArchivedAt
+// is set while the task is archived, and DeletedAt while it is in the trash.
 type Task struct {
-	ID          uuid.UUID  `json:"id"`
-	Title       string     `json:"title" validate:"required,min=1,max=200"`
-	Description *string    `json:"description,omitempty"`
-	Status      Status     `json:"status" validate:"oneof=Pending InProgress Completed Cancelled"`
-	DueDate     *time.Time `json:"due_date,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	Version     int        `json:"version"`
+	ID           uuid.UUID  `json:"id"`
+	ProjectID    *uuid.UUID `json:"project_id,omitempty"`
+	Key          *string    `json:"key,omitempty"`
+	Title        string     `json:"title" validate:"required,min=1,max=200"`
+	Description  *string    `json:"description,omitempty"`
+	Status       Status     `json:"status" validate:"required,max=50"`
+	Priority     Priority   `json:"priority"`
+	Severity     *Severity  `json:"severity,omitempty"`
+	DueDate      *time.Time `json:"due_date,omitempty"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	AssigneeID   *uuid.UUID `json:"assignee_id,omitempty"`
+	ReporterID   *uuid.UUID `json:"reporter_id,omitempty"`
+	Labels       []string   `json:"labels"`
+	Recurrence   *string    `json:"recurrence,omitempty"`
+	RecursFromID *uuid.UUID `json:"recurs_from_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	Version      int        `json:"version"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	ArchivedAt   *time.Time `json:"archived_at,omitempty"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
 }
 
-// CreateTaskRequest represents the payload for creating a new task
+// CreateTaskRequest represents the payload for creating a new task.
+// ReporterID defaults to the acting user when omitted, and a subtask created
+// without a ProjectID joins its parent's project. Priority defaults to DefaultPriority.
+// Recurrence is an RRULE and requires a DueDate.
 type CreateTaskRequest struct {
 	Title       string     `json:"title" validate:"required,min=1,max=200"`
 	Description *string    `json:"description,omitempty"`
-	Status      *Status    `json:"status,omitempty" validate:"omitempty,oneof=Pending InProgress Completed Cancelled"`
+	Status      *Status    `json:"status,omitempty" validate:"omitempty,min=1,max=50"`
+	Priority    *Priority  `json:"priority,omitempty"`
+	Severity    *Severity  `json:"severity,omitempty"`
 	DueDate     *time.Time `json:"due_date,omitempty"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+	ProjectID   *uuid.UUID `json:"project_id,omitempty"`
+	AssigneeID  *uuid.UUID `json:"assignee_id,omitempty"`
+	ReporterID  *uuid.UUID `json:"reporter_id,omitempty"`
+	Labels      []string   `json:"labels,omitempty"`
+	Recurrence  *string    `json:"recurrence,omitempty"`
 }
 
-// UpdateTaskRequest represents the payload for updating an existing task
+// UpdateTaskRequest represents the payload for updating an existing task.
+// Like every other omitted field, an omitted Priority is reset to its default,
+// an omitted Severity is cleared and an omitted Recurrence stops the task from
+// recurring.
 type UpdateTaskRequest struct {
 	Title       string     `json:"title" validate:"required,min=1,max=200"`
 	Description *string    `json:"description,omitempty"`
-	Status      Status     `json:"status" validate:"required,oneof=Pending InProgress Completed Cancelled"`
+	Status      Status     `json:"status" validate:"required,max=50"`
+	Priority    *Priority  `json:"priority,omitempty"`
+	Severity    *Severity  `json:"severity,omitempty"`
 	DueDate     *time.Time `json:"due_date,omitempty"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+	AssigneeID  *uuid.UUID `json:"assignee_id,omitempty"`
+	Recurrence  *string    `json:"recurrence,omitempty"`
 	Version     int        `json:"version" validate:"required,min=1"`
 }
 
-// PatchTaskRequest represents the payload for patching a task
+// PatchTaskRequest represents the payload for patching a task.
+// Unassign clears the assignee and cannot be combined with AssigneeID,
+// ClearSeverity and ClearParent likewise clear the severity and the parent,
+// making the task a top-level one, and an empty Recurrence stops the task
+// from recurring.
 type PatchTaskRequest struct {
-	Title       *string    `json:"title,omitempty" validate:"omitempty,min=1,max=200"`
-	Description *string    `json:"description,omitempty"`
-	Status      *Status    `json:"status,omitempty" validate:"omitempty,oneof=Pending InProgress Completed Cancelled"`
-	DueDate     *time.Time `json:"due_date,omitempty"`
-	Version     *int       `json:"version,omitempty" validate:"omitempty,min=1"`
+	Title         *string    `json:"title,omitempty" validate:"omitempty,min=1,max=200"`
+	Description   *string    `json:"description,omitempty"`
+	Status        *Status    `json:"status,omitempty" validate:"omitempty,min=1,max=50"`
+	Priority      *Priority  `json:"priority,omitempty"`
+	Severity      *Severity  `json:"severity,omitempty"`
+	ClearSeverity bool       `json:"clear_severity,omitempty"`
+	DueDate       *time.Time `json:"due_date,omitempty"`
+	ParentID      *uuid.UUID `json:"parent_id,omitempty"`
+	ClearParent   bool       `json:"clear_parent,omitempty"`
+	AssigneeID    *uuid.UUID `json:"assignee_id,omitempty"`
+	Unassign      bool       `json:"unassign,omitempty"`
+	AddLabels     []string   `json:"add_labels,omitempty"`
+	RemoveLabels  []string   `json:"remove_labels,omitempty"`
+	Recurrence    *string    `json:"recurrence,omitempty"`
+	Version       *int       `json:"version,omitempty" validate:"omitempty,min=1"`
+}
+
+// ArchiveTasksRequest represents the payload for archiving the tasks in a
+// done-category status that were completed before CompletedBefore, optionally
+// within one project
+type ArchiveTasksRequest struct {
+	CompletedBefore time.Time  `json:"completed_before"`
+	ProjectID       *uuid.UUID `json:"project_id,omitempty"`
 }
 
-// TaskList represents a paginated list of tasks
+// TaskList represents a page of tasks. Next and Prev mark the positions
+// after the last and before the first task, when there are tasks there.
 type TaskList struct {
-	Items []Task    `json:"items"`
-	Meta  PageMeta  `json:"meta"`
+	Items []Task      `json:"items"`
+	Meta  PageMeta    `json:"meta"`
+	Next  *TaskCursor `json:"-"`
+	Prev  *TaskCursor `json:"-"`
 }
 
-// PageMeta contains pagination metadata
+// PageMeta contains pagination metadata. Page is only set for pages selected
+// by number, and the totals only when they were counted.
 type PageMeta struct {
-	Page       int `json:"page"`
-	PageSize   int `json:"page_size"`
-	TotalItems int `json:"total_items"`
-	TotalPages int `json:"total_pages"`
+	Page       int  `json:"page,omitempty"`
+	PageSize   int  `json:"page_size"`
+	TotalItems *int `json:"total_items,omitempty"`
+	TotalPages *int `json:"total_pages,omitempty"`
+}
+
+// TaskCursor marks a position in a task list by the sort keys of a task: one
+// key per sort field, nil where the task has no value, followed by the
+// task's creation time and ID, which break ties
+type TaskCursor struct {
+	Keys      []any
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// NewTaskCursor returns the position of a task in a list sorted by sort
+func NewTaskCursor(t Task, sort []SortField) TaskCursor {
+	keys := make([]any, len(sort))
+	for i, s := range sort {
+		keys[i] = TaskSortKey(t, s.Field)
+	}
+	return TaskCursor{Keys: keys, CreatedAt: t.CreatedAt, ID: t.ID}
+}
+
+// TaskSortKey returns the value a task is sorted by for a sort field: a
+// time.Time, the priority's or severity's Rank, or nil when the task has no
+// value
+func TaskSortKey(t Task, field string) any {
+	var value *time.Time
+	switch field {
+	case "priority":
+		return t.Priority.Rank()
+	case "severity":
+		if t.Severity == nil {
+			return nil
+		}
+		return t.Severity.Rank()
+	case "created_at":
+		return t.CreatedAt
+	case "due_date":
+		value = t.DueDate
+	case "deleted_at":
+		value = t.DeletedAt
+	}
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+// SortField orders task lists by one field, descending when Desc is set.
+// Priority sorts from the most to the least urgent and severity from the most
+// to the least severe, with tasks without a severity last.
+type SortField struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc"`
 }
 
-// TaskFilter represents filter options for listing tasks
+// TaskFilter represents filter options for listing tasks. Sort lists the sort
+// fields in order of precedence. A page is selected either by number or by
+// a cursor taken from the same sort: the tasks After or Before it. The totals
+// are counted only with CountTotal. Deleted tasks are left out unless
+// IncludeDeleted is set; Deleted lists only them. Likewise archived tasks are
+// left out unless IncludeArchived is set, and Archived lists only them; the
+// trash lists deleted tasks whether archived or not.
 type TaskFilter struct {
-	Status *Status `json:"status,omitempty"`
-	Page   int     `json:"page"`
-	Size   int     `json:"size"`
-	Sort   string  `json:"sort"`
+	Status     *Status    `json:"status,omitempty"`
+	Priorities []Priority `json:"priorities,omitempty"`
+	Severities []Severity `json:"severities,omitempty"`
+	ParentID   *uuid.UUID `json:"parent_id,omitempty"`
+	ProjectID  *uuid.UUID `json:"project_id,omitempty"`
+	// AssigneeID matches tasks assigned to a user; Unassigned matches tasks with no assignee
+	AssigneeID *uuid.UUID `json:"assignee_id,omitempty"`
+	Unassigned bool       `json:"unassigned,omitempty"`
+	// Label filters match label names: any-of, all-of and none-of respectively
+	LabelsAny  []string `json:"labels_any,omitempty"`
+	LabelsAll  []string `json:"labels_all,omitempty"`
+	LabelsNone []string `json:"labels_none,omitempty"`
+	// Where is a parsed filter expression over TaskFilterFields
+	Where filter.Node `json:"-"`
+	// Search matches tasks by the words of their title and description
+	Search          *SearchQuery `json:"search,omitempty"`
+	IncludeDeleted  bool         `json:"include_deleted,omitempty"`
+	Deleted         bool         `json:"deleted,omitempty"`
+	IncludeArchived bool         `json:"include_archived,omitempty"`
+	Archived        bool         `json:"archived,omitempty"`
+	Page            int          `json:"page"`
+	Size            int          `json:"size"`
+	After           *TaskCursor  `json:"-"`
+	Before          *TaskCursor  `json:"-"`
+	CountTotal      bool         `json:"count_total,omitempty"`
+	Sort            []SortField  `json:"sort"`
+}
+
+// TaskFilterFields lists the task fields filter expressions may refer to,
+// named after their JSON fields. label matches any of a task's labels, and
+// is null when the task has none.
+var TaskFilterFields = filter.Schema{
+	"status":       {Type: filter.String},
+	"priority":     {Type: filter.String, Values: priorityNames()},
+	"severity":     {Type: filter.String, Values: severityNames(), Nullable: true},
+	"title":        {Type: filter.Text},
+	"description":  {Type: filter.Text, Nullable: true},
+	"label":        {Type: filter.String, Nullable: true},
+	"due_date":     {Type: filter.Time, Nullable: true},
+	"created_at":   {Type: filter.Time},
+	"updated_at":   {Type: filter.Time},
+	"completed_at": {Type: filter.Time, Nullable: true},
+	"project_id":   {Type: filter.UUID, Nullable: true},
+	"parent_id":    {Type: filter.UUID, Nullable: true},
+	"assignee_id":  {Type: filter.UUID, Nullable: true},
+	"reporter_id":  {Type: filter.UUID, Nullable: true},
+}
+
+// priorityNames lists the names of Priorities
+func priorityNames() []string {
+	names := make([]string, len(Priorities))
+	for i, p := range Priorities {
+		names[i] = string(p)
+	}
+	return names
+}
+
+// severityNames lists the names of Severities
+func severityNames() []string {
+	names := make([]string, len(Severities))
+	for i, s := range Severities {
+		names[i] = string(s)
+	}
+	return names
+}
+
+// TaskTree represents a task together with all of its nested subtasks
+type TaskTree struct {
+	Task
+	Children []TaskTree `json:"children"`
+}
+
+// ChildPolicy decides what happens to subtasks when their parent is deleted
+type ChildPolicy string
+
+// ChildPolicy constants
+const (
+	// ChildPolicyReparent moves subtasks up to the deleted task's parent
+	ChildPolicyReparent ChildPolicy = "reparent"
+	// ChildPolicyCascade deletes subtasks together with their parent
+	ChildPolicyCascade ChildPolicy = "cascade"
+)
+
+// WorkflowGraph describes which statuses exist and how tasks may move between them
+type WorkflowGraph struct {
+	Statuses    []Status            `json:"statuses"`
+	Initial     []Status            `json:"initial"`
+	Transitions map[Status][]Status `json:"transitions"`
 }
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// User is a member of the users directory who can report and be assigned tasks
+type User struct {
+	ID        uuid.UUID `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateUserRequest represents the payload for creating a user
+type CreateUserRequest struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// PatchUserRequest represents the payload for partially updating a user
+type PatchUserRequest struct {
+	Email *string `json:"email,omitempty"`
+	Name  *string `json:"name,omitempty"`
+}
+
+// Assignment records one change of a task's assignee. A nil AssigneeID means
+// the task was unassigned; a nil ChangedBy means the caller was anonymous.
+type Assignment struct {
+	ID                 uuid.UUID  `json:"id"`
+	TaskID             uuid.UUID  `json:"task_id"`
+	PreviousAssigneeID *uuid.UUID `json:"previous_assignee_id,omitempty"`
+	AssigneeID         *uuid.UUID `json:"assignee_id,omitempty"`
+	ChangedBy          *uuid.UUID `json:"changed_by,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// actorKey is the context key for the user performing a request
+type actorKey struct{}
+
+// WithActor returns a copy of ctx that carries the ID of the acting user
+func WithActor(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext returns the acting user stored by WithActor, if any
+func ActorFromContext(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(actorKey{}).(uuid.UUID)
+	return userID, ok
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// View is a saved task list. Filter holds the task list query parameters that
+// select its tasks, such as status or filter, as a client would send them;
+// Sort and Columns say how to show them. Private views are visible to their
+// owner only, shared views to everyone.
+type View struct {
+	ID        uuid.UUID         `json:"id"`
+	OwnerID   uuid.UUID         `json:"owner_id"`
+	Name      string            `json:"name"`
+	Filter    map[string]string `json:"filter"`
+	Sort      string            `json:"sort,omitempty"`
+	Columns   []string          `json:"columns"`
+	Shared    bool              `json:"shared"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// VisibleTo reports whether the user, nil when anonymous, may see the view
+func (v View) VisibleTo(user *uuid.UUID) bool {
+	return v.Shared || (user != nil && *user == v.OwnerID)
+}
+
+// CreateViewRequest represents the payload for saving a view
+type CreateViewRequest struct {
+	Name    string            `json:"name"`
+	Filter  map[string]string `json:"filter,omitempty"`
+	Sort    string            `json:"sort,omitempty"`
+	Columns []string          `json:"columns,omitempty"`
+	Shared  bool              `json:"shared"`
+}
+
+// PatchViewRequest represents the payload for partially updating a view. A
+// given filter or column set replaces the previous one.
+type PatchViewRequest struct {
+	Name    *string           `json:"name,omitempty"`
+	Filter  map[string]string `json:"filter,omitempty"`
+	Sort    *string           `json:"sort,omitempty"`
+	Columns []string          `json:"columns,omitempty"`
+	Shared  *bool             `json:"shared,omitempty"`
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is a subscription that receives task events over HTTP. An empty
+// Events list subscribes to every event type. The secret signs deliveries.
+type Webhook struct {
+	ID        uuid.UUID       `json:"id"`
+	URL       string          `json:"url"`
+	Secret    string          `json:"-"`
+	Events    []TaskEventType `json:"events"`
+	Active    bool            `json:"active"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// Subscribes reports whether the webhook receives events of type t
+func (w Webhook) Subscribes(t TaskEventType) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, t)
+}
+
+// CreateWebhookRequest represents the payload for creating a webhook.
+// A secret is generated when none is given; Active defaults to true.
+type CreateWebhookRequest struct {
+	URL    string          `json:"url"`
+	Secret *string         `json:"secret,omitempty"`
+	Events []TaskEventType `json:"events,omitempty"`
+	Active *bool           `json:"active,omitempty"`
+}
+
+// PatchWebhookRequest represents the payload for partially updating a webhook.
+// Setting Secret rotates the signing secret.
+type PatchWebhookRequest struct {
+	URL    *string          `json:"url,omitempty"`
+	Secret *string          `json:"secret,omitempty"`
+	Events *[]TaskEventType `json:"events,omitempty"`
+	Active *bool            `json:"active,omitempty"`
+}
+
+// DeliveryStatus is the state of a webhook delivery
+type DeliveryStatus string
+
+// Delivery statuses. A pending delivery is retried with backoff until it
+// succeeds or runs out of attempts, at which point it is dead.
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryDead      DeliveryStatus = "dead"
+)
+
+// Valid reports whether s is a known delivery status
+func (s DeliveryStatus) Valid() bool {
+	switch s {
+	case DeliveryPending, DeliveryDelivered, DeliveryDead:
+		return true
+	}
+	return false
+}
+
+// WebhookDelivery is one event queued for delivery to one webhook
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id"`
+	WebhookID      uuid.UUID       `json:"webhook_id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      TaskEventType   `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	LastError      *string         `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// DeliveryFilter selects the deliveries of a webhook, newest first
+type DeliveryFilter struct {
+	Status *DeliveryStatus
+	Limit  int
+}
@@ -0,0 +1,233 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"task-svc/internal/config"
+	"task-svc/internal/domain"
+	"task-svc/internal/service"
+)
+
+// multipartOverhead is the room left in an upload request for the multipart
+// framing and any small form fields sent alongside the file
+const multipartOverhead = 1 << 20
+
+// AttachmentHandler handles HTTP requests for task attachments
+type AttachmentHandler struct {
+	responder
+	service service.AttachmentService
+	config  config.AttachmentConfig
+}
+
+// NewAttachmentHandler creates a new attachment handler
+func NewAttachmentHandler(service service.AttachmentService, cfg config.AttachmentConfig, logger *slog.Logger) *AttachmentHandler {
+	return &AttachmentHandler{
+		responder: responder{logger: logger},
+		service:   service,
+		config:    cfg,
+	}
+}
+
+// ListAttachments handles GET /v1/tasks/{id}/attachments
+func (h *AttachmentHandler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	taskID, ok := h.parseTaskID(w, r)
+	if !ok {
+		return
+	}
+
+	attachments, err := h.service.List(r.Context(), taskID)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list attachments")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainAttachments(attachments))
+}
+
+// UploadAttachment handles POST /v1/tasks/{id}/attachments. The file is read
+// from the "file" field of a multipart/form-data body and streamed to the
+// service without being buffered in memory.
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	taskID, ok := h.parseTaskID(w, r)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.config.MaxSize+multipartOverhead)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Request body must be multipart/form-data", nil)
+		return
+	}
+
+	var part *multipart.Part
+	for part == nil || part.FormName() != "file" {
+		part, err = reader.NextPart()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed",
+					map[string]interface{}{"file": "This field is required"})
+				return
+			}
+			h.respondWithBodyError(w, err)
+			return
+		}
+	}
+
+	content := &bodyReader{r: part}
+	attachment, err := h.service.Upload(r.Context(), taskID, domain.UploadAttachmentRequest{
+		Filename:    part.FileName(),
+		ContentType: part.Header.Get("Content-Type"),
+		Content:     content,
+	})
+	if err != nil {
+		if content.err != nil {
+			h.respondWithBodyError(w, content.err)
+			return
+		}
+		h.respondWithServiceError(w, err, "Failed to upload attachment")
+		return
+	}
+
+	w.Header().Set("Location", r.URL.Path+"/"+attachment.ID.String())
+	h.respondWithJSON(w, http.StatusCreated, fromDomainAttachment(*attachment))
+}
+
+// GetAttachment handles GET /v1/tasks/{id}/attachments/{attachmentId}
+func (h *AttachmentHandler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	taskID, attachmentID, ok := h.parseAttachmentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	attachment, err := h.service.Get(r.Context(), taskID, attachmentID)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to retrieve attachment")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainAttachment(*attachment))
+}
+
+// DownloadAttachment handles GET /v1/tasks/{id}/attachments/{attachmentId}/content.
+// The checksum doubles as the ETag.
+func (h *AttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	taskID, attachmentID, ok := h.parseAttachmentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	attachment, err := h.service.Get(r.Context(), taskID, attachmentID)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to retrieve attachment")
+		return
+	}
+
+	etag := `"` + attachment.ChecksumSHA256 + `"`
+	if r.Header.Get("If-None-Match") == etag {
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	_, content, err := h.service.Open(r.Context(), taskID, attachmentID)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to download attachment")
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, content); err != nil {
+		h.logger.Error("Failed to stream attachment", "attachment_id", attachment.ID, "error", err)
+	}
+}
+
+// DeleteAttachment handles DELETE /v1/tasks/{id}/attachments/{attachmentId}
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	taskID, attachmentID, ok := h.parseAttachmentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), taskID, attachmentID); err != nil {
+		h.respondWithServiceError(w, err, "Failed to delete attachment")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// respondWithBodyError reports a failure to read the upload request body:
+// 413 when it exceeds the size limit and 400 otherwise
+func (h *AttachmentHandler) respondWithBodyError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		h.respondWithServiceError(w, service.ErrAttachmentTooLarge, "Failed to upload attachment")
+		return
+	}
+	h.respondWithError(w, http.StatusBadRequest, "bad_request", "Malformed multipart body", nil)
+}
+
+// parseTaskID reads the task ID from the URL
+func (h *AttachmentHandler) parseTaskID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	taskID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
+		return uuid.Nil, false
+	}
+	return taskID, true
+}
+
+// parseAttachmentIDs reads the task and attachment IDs from the URL
+func (h *AttachmentHandler) parseAttachmentIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	taskID, ok := h.parseTaskID(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	attachmentID, err := uuid.Parse(chi.URLParam(r, "attachmentId"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid attachment ID", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return taskID, attachmentID, true
+}
+
+// bodyReader remembers the first error met while reading the request body, so
+// that it can be told apart from failures further down the upload
+type bodyReader struct {
+	r   io.Reader
+	err error
+}
+
+func (b *bodyReader) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) && b.err == nil {
+		b.err = err
+	}
+	return n, err
+}
+
+// RegisterTaskRoutes registers the attachment routes below /tasks/{id}
+func (h *AttachmentHandler) RegisterTaskRoutes(r chi.Router) {
+	r.Get("/{id}/attachments", h.ListAttachments)
+	r.Post("/{id}/attachments", h.UploadAttachment)
+	r.Get("/{id}/attachments/{attachmentId}", h.GetAttachment)
+	r.Get("/{id}/attachments/{attachmentId}/content", h.DownloadAttachment)
+	r.Delete("/{id}/attachments/{attachmentId}", h.DeleteAttachment)
+}
@@ -0,0 +1,218 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"task-svc/internal/config"
+	"task-svc/internal/domain"
+	"task-svc/internal/service"
+	"task-svc/pkg/pagination"
+)
+
+// CommentHandler handles HTTP requests for task comments
+type CommentHandler struct {
+	responder
+	service          service.CommentService
+	paginationConfig config.PaginationConfig
+}
+
+// NewCommentHandler creates a new comment handler
+func NewCommentHandler(service service.CommentService, cfg config.PaginationConfig, logger *slog.Logger) *CommentHandler {
+	return &CommentHandler{
+		responder:        responder{logger: logger},
+		service:          service,
+		paginationConfig: cfg,
+	}
+}
+
+// ListComments handles GET /v1/tasks/{id}/comments
+func (h *CommentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	taskID, ok := h.parseTaskID(w, r)
+	if !ok {
+		return
+	}
+
+	_, limit := pagination.Parse("", r.URL.Query().Get("limit"), pagination.DefaultParams{
+		DefaultPage: 1,
+		DefaultSize: h.paginationConfig.DefaultSize,
+		MaxSize:     h.paginationConfig.MaxSize,
+	})
+	filter := domain.CommentFilter{Limit: limit}
+
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		after, err := decodeCommentCursor(cursor)
+		if err != nil {
+			h.respondWithError(w, http.StatusBadRequest, "invalid_cursor", "Invalid cursor", nil)
+			return
+		}
+		filter.After = after
+	}
+
+	comments, err := h.service.List(r.Context(), taskID, filter)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list comments")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainCommentList(comments))
+}
+
+// CreateComment handles POST /v1/tasks/{id}/comments
+func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	taskID, ok := h.parseTaskID(w, r)
+	if !ok {
+		return
+	}
+
+	var payload CreateCommentPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	comment, err := h.service.Create(r.Context(), taskID, payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to create comment")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, fromDomainComment(*comment))
+}
+
+// GetComment handles GET /v1/tasks/{id}/comments/{commentId}
+func (h *CommentHandler) GetComment(w http.ResponseWriter, r *http.Request) {
+	taskID, commentID, ok := h.parseCommentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	comment, err := h.service.Get(r.Context(), taskID, commentID)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to retrieve comment")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainComment(*comment))
+}
+
+// UpdateComment handles PATCH /v1/tasks/{id}/comments/{commentId}
+func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	taskID, commentID, ok := h.parseCommentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var payload UpdateCommentPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+
+	// Check for version in header if not in body
+	if payload.Version == 0 {
+		if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+			version, err := strconv.Atoi(ifMatch)
+			if err == nil && version > 0 {
+				payload.Version = version
+			}
+		}
+	}
+
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	comment, err := h.service.Update(r.Context(), taskID, commentID, payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to update comment")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainComment(*comment))
+}
+
+// DeleteComment handles DELETE /v1/tasks/{id}/comments/{commentId}
+func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	taskID, commentID, ok := h.parseCommentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), taskID, commentID); err != nil {
+		h.respondWithServiceError(w, err, "Failed to delete comment")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseTaskID reads the task ID from the URL
+func (h *CommentHandler) parseTaskID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	taskID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
+		return uuid.Nil, false
+	}
+	return taskID, true
+}
+
+// parseCommentIDs reads the task and comment IDs from the URL
+func (h *CommentHandler) parseCommentIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	taskID, ok := h.parseTaskID(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	commentID, err := uuid.Parse(chi.URLParam(r, "commentId"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid comment ID", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return taskID, commentID, true
+}
+
+// decodeCommentCursor reads a cursor produced by encodeCommentCursor
+func decodeCommentCursor(cursor string) (*domain.CommentCursor, error) {
+	values, err := pagination.DecodeCursor(cursor, 2)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, values[0])
+	if err != nil {
+		return nil, pagination.ErrInvalidCursor
+	}
+	id, err := uuid.Parse(values[1])
+	if err != nil {
+		return nil, pagination.ErrInvalidCursor
+	}
+	return &domain.CommentCursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// encodeCommentCursor turns the position after a page of comments into a cursor
+func encodeCommentCursor(c domain.CommentCursor) string {
+	return pagination.EncodeCursor(c.CreatedAt.UTC().Format(time.RFC3339Nano), c.ID.String())
+}
+
+// RegisterTaskRoutes registers the comment routes below /tasks/{id}
+func (h *CommentHandler) RegisterTaskRoutes(r chi.Router) {
+	r.Get("/{id}/comments", h.ListComments)
+	r.Post("/{id}/comments", h.CreateComment)
+	r.Get("/{id}/comments/{commentId}", h.GetComment)
+	r.Patch("/{id}/comments/{commentId}", h.UpdateComment)
+	r.Delete("/{id}/comments/{commentId}", h.DeleteComment)
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ListDependencies handles GET /v1/tasks/{id}/dependencies
+func (h *TaskHandler) ListDependencies(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
+		return
+	}
+
+	deps, err := h.service.Dependencies(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list dependencies")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainTaskDependencies(*deps))
+}
+
+// AddDependency handles POST /v1/tasks/{id}/dependencies
+func (h *TaskHandler) AddDependency(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
+		return
+	}
+
+	var payload AddDependencyPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	dep, err := h.service.AddDependency(r.Context(), id, payload.BlockedBy)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to add dependency")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, fromDomainDependency(*dep))
+}
+
+// RemoveDependency handles DELETE /v1/tasks/{id}/dependencies/{blockerId}
+func (h *TaskHandler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
+		return
+	}
+
+	blockerID, err := uuid.Parse(chi.URLParam(r, "blockerId"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid blocker task ID", nil)
+		return
+	}
+
+	if err := h.service.RemoveDependency(r.Context(), id, blockerID); err != nil {
+		h.respondWithServiceError(w, err, "Failed to remove dependency")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,8 +1,11 @@
 package http
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/google/uuid"
+
 	"task-svc/internal/domain"
 )
 
@@ -10,28 +13,51 @@ import (
 
 // CreateTaskPayload represents the HTTP request body to create a task
 type CreateTaskPayload struct {
-	Title       string         `json:"title" validate:"required,min=1,max=200"`
-	Description *string        `json:"description,omitempty"`
-	Status      *domain.Status `json:"status,omitempty" validate:"omitempty,oneof=Pending InProgress Completed Cancelled"`
-	DueDate     *time.Time     `json:"due_date,omitempty"`
+	Title       string           `json:"title" validate:"required,min=1,max=200"`
+	Description *string          `json:"description,omitempty"`
+	Status      *domain.Status   `json:"status,omitempty" validate:"omitempty,min=1,max=50"`
+	Priority    *domain.Priority `json:"priority,omitempty" validate:"omitempty,oneof=Urgent High Medium Low"`
+	Severity    *domain.Severity `json:"severity,omitempty" validate:"omitempty,oneof=Critical Major Minor Trivial"`
+	DueDate     *time.Time       `json:"due_date,omitempty"`
+	ParentID    *uuid.UUID       `json:"parent_id,omitempty"`
+	ProjectID   *uuid.UUID       `json:"project_id,omitempty"`
+	AssigneeID  *uuid.UUID       `json:"assignee_id,omitempty"`
+	ReporterID  *uuid.UUID       `json:"reporter_id,omitempty"`
+	Labels      []string         `json:"labels,omitempty" validate:"omitempty,dive,min=1,max=50"`
+	Recurrence  *string          `json:"recurrence,omitempty" validate:"omitempty,max=500"`
 }
 
 // UpdateTaskPayload represents the HTTP request body to update a task
 type UpdateTaskPayload struct {
-	Title       string        `json:"title" validate:"required,min=1,max=200"`
-	Description *string       `json:"description,omitempty"`
-	Status      domain.Status `json:"status" validate:"required,oneof=Pending InProgress Completed Cancelled"`
-	DueDate     *time.Time    `json:"due_date,omitempty"`
-	Version     int           `json:"version" validate:"required,min=1"`
+	Title       string           `json:"title" validate:"required,min=1,max=200"`
+	Description *string          `json:"description,omitempty"`
+	Status      domain.Status    `json:"status" validate:"required,max=50"`
+	Priority    *domain.Priority `json:"priority,omitempty" validate:"omitempty,oneof=Urgent High Medium Low"`
+	Severity    *domain.Severity `json:"severity,omitempty" validate:"omitempty,oneof=Critical Major Minor Trivial"`
+	DueDate     *time.Time       `json:"due_date,omitempty"`
+	ParentID    *uuid.UUID       `json:"parent_id,omitempty"`
+	AssigneeID  *uuid.UUID       `json:"assignee_id,omitempty"`
+	Recurrence  *string          `json:"recurrence,omitempty" validate:"omitempty,max=500"`
+	Version     int              `json:"version" validate:"required,min=1"`
 }
 
 // PatchTaskPayload represents the HTTP request body to partially update a task
 type PatchTaskPayload struct {
-	Title       *string        `json:"title,omitempty" validate:"omitempty,min=1,max=200"`
-	Description *string        `json:"description,omitempty"`
-	Status      *domain.Status `json:"status,omitempty" validate:"omitempty,oneof=Pending InProgress Completed Cancelled"`
-	DueDate     *time.Time     `json:"due_date,omitempty"`
-	Version     *int           `json:"version,omitempty" validate:"omitempty,min=1"`
+	Title         *string          `json:"title,omitempty" validate:"omitempty,min=1,max=200"`
+	Description   *string          `json:"description,omitempty"`
+	Status        *domain.Status   `json:"status,omitempty" validate:"omitempty,min=1,max=50"`
+	Priority      *domain.Priority `json:"priority,omitempty" validate:"omitempty,oneof=Urgent High Medium Low"`
+	Severity      *domain.Severity `json:"severity,omitempty" validate:"omitempty,oneof=Critical Major Minor Trivial"`
+	ClearSeverity bool             `json:"clear_severity,omitempty" validate:"excluded_with=Severity"`
+	DueDate       *time.Time       `json:"due_date,omitempty"`
+	ParentID      *uuid.UUID       `json:"parent_id,omitempty"`
+	ClearParent   bool             `json:"clear_parent,omitempty" validate:"excluded_with=ParentID"`
+	AssigneeID    *uuid.UUID       `json:"assignee_id,omitempty"`
+	Unassign      bool             `json:"unassign,omitempty" validate:"excluded_with=AssigneeID"`
+	AddLabels     []string         `json:"add_labels,omitempty" validate:"omitempty,dive,min=1,max=50"`
+	RemoveLabels  []string         `json:"remove_labels,omitempty" validate:"omitempty,dive,min=1,max=50"`
+	Recurrence    *string          `json:"recurrence,omitempty" validate:"omitempty,max=500"`
+	Version       *int             `json:"version,omitempty" validate:"omitempty,min=1"`
 }
 
 // ToDomain converts CreateTaskPayload to domain.CreateTaskRequest
@@ -40,17 +66,65 @@ func (p CreateTaskPayload) ToDomain() domain.CreateTaskRequest {
 		Title:       p.Title,
 		Description: p.Description,
 		Status:      p.Status,
+		Priority:    p.Priority,
+		Severity:    p.Severity,
 		DueDate:     p.DueDate,
+		ParentID:    p.ParentID,
+		ProjectID:   p.ProjectID,
+		AssigneeID:  p.AssigneeID,
+		ReporterID:  p.ReporterID,
+		Labels:      p.Labels,
+		Recurrence:  p.Recurrence,
+	}
+}
+
+// RevertTaskPayload represents the HTTP request body to revert a task to an
+// earlier version. Version may also be given in the If-Match header.
+type RevertTaskPayload struct {
+	ToVersion int `json:"to_version" validate:"required,min=1"`
+	Version   int `json:"version" validate:"required,min=1"`
+}
+
+// ToDomain converts RevertTaskPayload to domain.RevertTaskRequest
+func (p RevertTaskPayload) ToDomain() domain.RevertTaskRequest {
+	return domain.RevertTaskRequest{
+		ToVersion: p.ToVersion,
+		Version:   p.Version,
 	}
 }
 
+// ArchiveTasksPayload represents the HTTP request body to archive the tasks
+// completed before a point in time
+type ArchiveTasksPayload struct {
+	CompletedBefore *time.Time `json:"completed_before" validate:"required"`
+	ProjectID       *uuid.UUID `json:"project_id,omitempty"`
+}
+
+// ToDomain converts ArchiveTasksPayload to domain.ArchiveTasksRequest
+func (p ArchiveTasksPayload) ToDomain() domain.ArchiveTasksRequest {
+	return domain.ArchiveTasksRequest{
+		CompletedBefore: *p.CompletedBefore,
+		ProjectID:       p.ProjectID,
+	}
+}
+
+// ArchiveTasksResponse reports how many tasks a bulk archive archived
+type ArchiveTasksResponse struct {
+	Archived int `json:"archived"`
+}
+
 // ToDomain converts UpdateTaskPayload to domain.UpdateTaskRequest
 func (p UpdateTaskPayload) ToDomain() domain.UpdateTaskRequest {
 	return domain.UpdateTaskRequest{
 		Title:       p.Title,
 		Description: p.Description,
 		Status:      p.Status,
+		Priority:    p.Priority,
+		Severity:    p.Severity,
 		DueDate:     p.DueDate,
+		ParentID:    p.ParentID,
+		AssigneeID:  p.AssigneeID,
+		Recurrence:  p.Recurrence,
 		Version:     p.Version,
 	}
 }
@@ -58,38 +132,64 @@ func (p UpdateTaskPayload) ToDomain() domain.UpdateTaskRequest {
 // ToDomain converts PatchTaskPayload to domain.PatchTaskRequest
 func (p PatchTaskPayload) ToDomain() domain.PatchTaskRequest {
 	return domain.PatchTaskRequest{
-		Title:       p.Title,
-		Description: p.Description,
-		Status:      p.Status,
-		DueDate:     p.DueDate,
-		Version:     p.Version,
+		Title:         p.Title,
+		Description:   p.Description,
+		Status:        p.Status,
+		Priority:      p.Priority,
+		Severity:      p.Severity,
+		ClearSeverity: p.ClearSeverity,
+		DueDate:       p.DueDate,
+		ParentID:      p.ParentID,
+		ClearParent:   p.ClearParent,
+		AssigneeID:    p.AssigneeID,
+		Unassign:      p.Unassign,
+		AddLabels:     p.AddLabels,
+		RemoveLabels:  p.RemoveLabels,
+		Recurrence:    p.Recurrence,
+		Version:       p.Version,
 	}
 }
 
 // TaskResponse is the response shape for a task
 type TaskResponse struct {
-	ID          string  `json:"id"`
-	Title       string  `json:"title"`
-	Description *string `json:"description,omitempty"`
-	Status      string  `json:"status"`
-	DueDate     *string `json:"due_date,omitempty"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
-	Version     int     `json:"version"`
+	ID           string   `json:"id"`
+	Key          *string  `json:"key,omitempty"`
+	ProjectID    *string  `json:"project_id,omitempty"`
+	Title        string   `json:"title"`
+	Description  *string  `json:"description,omitempty"`
+	Status       string   `json:"status"`
+	Priority     string   `json:"priority"`
+	Severity     *string  `json:"severity,omitempty"`
+	DueDate      *string  `json:"due_date,omitempty"`
+	ParentID     *string  `json:"parent_id,omitempty"`
+	AssigneeID   *string  `json:"assignee_id,omitempty"`
+	ReporterID   *string  `json:"reporter_id,omitempty"`
+	Labels       []string `json:"labels"`
+	Recurrence   *string  `json:"recurrence,omitempty"`
+	RecursFromID *string  `json:"recurs_from_id,omitempty"`
+	CreatedAt    string   `json:"created_at"`
+	UpdatedAt    string   `json:"updated_at"`
+	Version      int      `json:"version"`
+	CompletedAt  *string  `json:"completed_at,omitempty"`
+	ArchivedAt   *string  `json:"archived_at,omitempty"`
+	DeletedAt    *string  `json:"deleted_at,omitempty"`
 }
 
-// TaskListResponse wraps a list of tasks along with pagination metadata
+// TaskListResponse wraps a list of tasks along with pagination metadata and
+// the cursors of the neighbouring pages
 type TaskListResponse struct {
-	Items []TaskResponse `json:"items"`
-	Meta  PageMeta       `json:"meta"`
+	Items      []TaskResponse `json:"items"`
+	Meta       PageMeta       `json:"meta"`
+	NextCursor *string        `json:"next_cursor"`
+	PrevCursor *string        `json:"prev_cursor"`
 }
 
 // PageMeta mirrors domain.PageMeta but keeps the HTTP boundary explicit
 type PageMeta struct {
-	Page       int `json:"page"`
-	PageSize   int `json:"page_size"`
-	TotalItems int `json:"total_items"`
-	TotalPages int `json:"total_pages"`
+	Page       int  `json:"page,omitempty"`
+	PageSize   int  `json:"page_size"`
+	TotalItems *int `json:"total_items,omitempty"`
+	TotalPages *int `json:"total_pages,omitempty"`
 }
 
 // fromDomainTask maps a domain.Task to TaskResponse
@@ -99,26 +199,62 @@ func fromDomainTask(t domain.Task) TaskResponse {
 		s := t.DueDate.UTC().Format(time.RFC3339)
 		dueStr = &s
 	}
+	var severity *string
+	if t.Severity != nil {
+		s := string(*t.Severity)
+		severity = &s
+	}
+	labels := t.Labels
+	if labels == nil {
+		labels = []string{}
+	}
 	return TaskResponse{
-		ID:          t.ID.String(),
-		Title:       t.Title,
-		Description: t.Description,
-		Status:      string(t.Status),
-		DueDate:     dueStr,
-		CreatedAt:   t.CreatedAt.UTC().Format(time.RFC3339),
-		UpdatedAt:   t.UpdatedAt.UTC().Format(time.RFC3339),
-		Version:     t.Version,
+		ID:           t.ID.String(),
+		Key:          t.Key,
+		ProjectID:    optionalID(t.ProjectID),
+		Title:        t.Title,
+		Description:  t.Description,
+		Status:       string(t.Status),
+		Priority:     string(t.Priority),
+		Severity:     severity,
+		DueDate:      dueStr,
+		ParentID:     optionalID(t.ParentID),
+		AssigneeID:   optionalID(t.AssigneeID),
+		ReporterID:   optionalID(t.ReporterID),
+		Labels:       labels,
+		Recurrence:   t.Recurrence,
+		RecursFromID: optionalID(t.RecursFromID),
+		CreatedAt:    t.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:    t.UpdatedAt.UTC().Format(time.RFC3339),
+		Version:      t.Version,
+		CompletedAt:  optionalTime(t.CompletedAt),
+		ArchivedAt:   optionalTime(t.ArchivedAt),
+		DeletedAt:    optionalTime(t.DeletedAt),
 	}
 }
 
+// optionalID formats an optional ID, keeping nil as nil
+func optionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+// optionalTime formats an optional timestamp, keeping nil as nil
+func optionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.UTC().Format(time.RFC3339)
+	return &s
+}
+
 // fromDomainTaskList maps a domain.TaskList to TaskListResponse
 func fromDomainTaskList(l *domain.TaskList) TaskListResponse {
-	items := make([]TaskResponse, 0, len(l.Items))
-	for _, it := range l.Items {
-		items = append(items, fromDomainTask(it))
-	}
 	return TaskListResponse{
-		Items: items,
+		Items: fromDomainTasks(l.Items),
 		Meta: PageMeta{
 			Page:       l.Meta.Page,
 			PageSize:   l.Meta.PageSize,
@@ -128,4 +264,916 @@ func fromDomainTaskList(l *domain.TaskList) TaskListResponse {
 	}
 }
 
+// SearchHitResponse is the response shape for a task matched by a search.
+// Matching words are wrapped in <mark> tags; the rest of the text is not
+// escaped.
+type SearchHitResponse struct {
+	TaskResponse
+	Rank           float64 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        *string `json:"snippet,omitempty"`
+}
+
+// SearchResultsResponse wraps a page of search hits along with pagination metadata
+type SearchResultsResponse struct {
+	Items []SearchHitResponse `json:"items"`
+	Meta  PageMeta            `json:"meta"`
+}
+
+// fromDomainSearchResults maps domain.TaskSearchResults to SearchResultsResponse
+func fromDomainSearchResults(r *domain.TaskSearchResults) SearchResultsResponse {
+	items := make([]SearchHitResponse, 0, len(r.Items))
+	for _, hit := range r.Items {
+		items = append(items, SearchHitResponse{
+			TaskResponse:   fromDomainTask(hit.Task),
+			Rank:           hit.Rank,
+			TitleHighlight: hit.TitleHighlight,
+			Snippet:        hit.Snippet,
+		})
+	}
+	return SearchResultsResponse{
+		Items: items,
+		Meta: PageMeta{
+			Page:       r.Meta.Page,
+			PageSize:   r.Meta.PageSize,
+			TotalItems: r.Meta.TotalItems,
+			TotalPages: r.Meta.TotalPages,
+		},
+	}
+}
+
+// TaskTreeResponse is the response shape for a task with its nested subtasks
+type TaskTreeResponse struct {
+	TaskResponse
+	Children []TaskTreeResponse `json:"children"`
+}
+
+// fromDomainTaskTree maps a domain.TaskTree to TaskTreeResponse
+func fromDomainTaskTree(t domain.TaskTree) TaskTreeResponse {
+	children := make([]TaskTreeResponse, 0, len(t.Children))
+	for _, c := range t.Children {
+		children = append(children, fromDomainTaskTree(c))
+	}
+	return TaskTreeResponse{
+		TaskResponse: fromDomainTask(t.Task),
+		Children:     children,
+	}
+}
+
+// AddDependencyPayload represents the HTTP request body to add a blocker to a task
+type AddDependencyPayload struct {
+	BlockedBy uuid.UUID `json:"blocked_by" validate:"required"`
+}
+
+// DependencyResponse is the response shape for a dependency edge
+type DependencyResponse struct {
+	BlockerID string `json:"blocker_id"`
+	BlockedID string `json:"blocked_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// TaskDependenciesResponse lists the tasks on either side of a task's dependencies
+type TaskDependenciesResponse struct {
+	BlockedBy []TaskResponse `json:"blocked_by"`
+	Blocks    []TaskResponse `json:"blocks"`
+}
+
+// fromDomainDependency maps a domain.Dependency to DependencyResponse
+func fromDomainDependency(d domain.Dependency) DependencyResponse {
+	return DependencyResponse{
+		BlockerID: d.BlockerID.String(),
+		BlockedID: d.BlockedID.String(),
+		CreatedAt: d.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// fromDomainTaskDependencies maps a domain.TaskDependencies to TaskDependenciesResponse
+func fromDomainTaskDependencies(d domain.TaskDependencies) TaskDependenciesResponse {
+	return TaskDependenciesResponse{
+		BlockedBy: fromDomainTasks(d.BlockedBy),
+		Blocks:    fromDomainTasks(d.Blocks),
+	}
+}
+
+// fromDomainTasks maps a slice of domain.Task to TaskResponse values
+func fromDomainTasks(tasks []domain.Task) []TaskResponse {
+	items := make([]TaskResponse, 0, len(tasks))
+	for _, t := range tasks {
+		items = append(items, fromDomainTask(t))
+	}
+	return items
+}
+
+// WorkflowResponse describes the status workflow so clients can offer only valid moves
+type WorkflowResponse struct {
+	Statuses    []string            `json:"statuses"`
+	Initial     []string            `json:"initial"`
+	Transitions map[string][]string `json:"transitions"`
+}
+
+// fromDomainWorkflow maps a domain.WorkflowGraph to WorkflowResponse
+func fromDomainWorkflow(g domain.WorkflowGraph) WorkflowResponse {
+	resp := WorkflowResponse{
+		Statuses:    statusStrings(g.Statuses),
+		Initial:     statusStrings(g.Initial),
+		Transitions: make(map[string][]string, len(g.Transitions)),
+	}
+	for from, to := range g.Transitions {
+		resp.Transitions[string(from)] = statusStrings(to)
+	}
+	return resp
+}
+
+// statusStrings converts statuses to their string form
+func statusStrings(statuses []domain.Status) []string {
+	out := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		out = append(out, string(s))
+	}
+	return out
+}
+
+// CreateStatusPayload represents the HTTP request body to create a status
+type CreateStatusPayload struct {
+	ProjectID *uuid.UUID `json:"project_id,omitempty"`
+	Name      string     `json:"name" validate:"required,min=1,max=50"`
+	Category  string     `json:"category" validate:"required,oneof=todo doing done"`
+	Position  *int       `json:"position,omitempty" validate:"omitempty,min=0"`
+	Color     *string    `json:"color,omitempty" validate:"omitempty,hexcolor"`
+}
+
+// PatchStatusPayload represents the HTTP request body to partially update a status
+type PatchStatusPayload struct {
+	Name     *string `json:"name,omitempty" validate:"omitempty,min=1,max=50"`
+	Category *string `json:"category,omitempty" validate:"omitempty,oneof=todo doing done"`
+	Position *int    `json:"position,omitempty" validate:"omitempty,min=0"`
+	Color    *string `json:"color,omitempty" validate:"omitempty,hexcolor"`
+}
+
+// ToDomain converts CreateStatusPayload to domain.CreateStatusRequest
+func (p CreateStatusPayload) ToDomain() domain.CreateStatusRequest {
+	return domain.CreateStatusRequest{
+		ProjectID: p.ProjectID,
+		Name:      domain.Status(p.Name),
+		Category:  domain.StatusCategory(p.Category),
+		Position:  p.Position,
+		Color:     p.Color,
+	}
+}
+
+// ToDomain converts PatchStatusPayload to domain.PatchStatusRequest
+func (p PatchStatusPayload) ToDomain() domain.PatchStatusRequest {
+	req := domain.PatchStatusRequest{
+		Position: p.Position,
+		Color:    p.Color,
+	}
+	if p.Name != nil {
+		name := domain.Status(*p.Name)
+		req.Name = &name
+	}
+	if p.Category != nil {
+		category := domain.StatusCategory(*p.Category)
+		req.Category = &category
+	}
+	return req
+}
+
+// StatusResponse is the response shape for a status
+type StatusResponse struct {
+	ID        string  `json:"id"`
+	ProjectID *string `json:"project_id,omitempty"`
+	Name      string  `json:"name"`
+	Category  string  `json:"category"`
+	Position  int     `json:"position"`
+	Color     string  `json:"color"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+// StatusListResponse wraps the list of statuses
+type StatusListResponse struct {
+	Items []StatusResponse `json:"items"`
+}
+
+// fromDomainStatusSet maps a domain.StatusSet to StatusListResponse
+func fromDomainStatusSet(set domain.StatusSet) StatusListResponse {
+	items := make([]StatusResponse, 0, len(set))
+	for _, s := range set {
+		items = append(items, fromDomainStatus(s))
+	}
+	return StatusListResponse{Items: items}
+}
+
+// fromDomainStatus maps a domain.StatusDefinition to StatusResponse
+func fromDomainStatus(s domain.StatusDefinition) StatusResponse {
+	return StatusResponse{
+		ID:        s.ID.String(),
+		ProjectID: optionalID(s.ProjectID),
+		Name:      string(s.Name),
+		Category:  string(s.Category),
+		Position:  s.Position,
+		Color:     s.Color,
+		CreatedAt: s.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: s.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// CreateLabelPayload represents the HTTP request body to create a label
+type CreateLabelPayload struct {
+	Name        string  `json:"name" validate:"required,min=1,max=50"`
+	Color       *string `json:"color,omitempty" validate:"omitempty,hexcolor"`
+	Description *string `json:"description,omitempty"`
+}
+
+// PatchLabelPayload represents the HTTP request body to partially update a label
+type PatchLabelPayload struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=1,max=50"`
+	Color       *string `json:"color,omitempty" validate:"omitempty,hexcolor"`
+	Description *string `json:"description,omitempty"`
+}
+
+// ToDomain converts CreateLabelPayload to domain.CreateLabelRequest
+func (p CreateLabelPayload) ToDomain() domain.CreateLabelRequest {
+	return domain.CreateLabelRequest{
+		Name:        p.Name,
+		Color:       p.Color,
+		Description: p.Description,
+	}
+}
+
+// ToDomain converts PatchLabelPayload to domain.PatchLabelRequest
+func (p PatchLabelPayload) ToDomain() domain.PatchLabelRequest {
+	return domain.PatchLabelRequest{
+		Name:        p.Name,
+		Color:       p.Color,
+		Description: p.Description,
+	}
+}
+
+// LabelResponse is the response shape for a label
+type LabelResponse struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Color       string  `json:"color"`
+	Description *string `json:"description,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+// LabelListResponse wraps the list of labels
+type LabelListResponse struct {
+	Items []LabelResponse `json:"items"`
+}
+
+// fromDomainLabel maps a domain.Label to LabelResponse
+func fromDomainLabel(l domain.Label) LabelResponse {
+	return LabelResponse{
+		ID:          l.ID.String(),
+		Name:        l.Name,
+		Color:       l.Color,
+		Description: l.Description,
+		CreatedAt:   l.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:   l.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// fromDomainLabels maps a slice of domain.Label to LabelListResponse
+func fromDomainLabels(labels []domain.Label) LabelListResponse {
+	items := make([]LabelResponse, 0, len(labels))
+	for _, l := range labels {
+		items = append(items, fromDomainLabel(l))
+	}
+	return LabelListResponse{Items: items}
+}
+
+// CreateUserPayload represents the HTTP request body to add a user to the directory
+type CreateUserPayload struct {
+	Email string `json:"email" validate:"required,email,max=254"`
+	Name  string `json:"name" validate:"required,min=1,max=100"`
+}
+
+// PatchUserPayload represents the HTTP request body to partially update a user
+type PatchUserPayload struct {
+	Email *string `json:"email,omitempty" validate:"omitempty,email,max=254"`
+	Name  *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// ToDomain converts CreateUserPayload to domain.CreateUserRequest
+func (p CreateUserPayload) ToDomain() domain.CreateUserRequest {
+	return domain.CreateUserRequest{
+		Email: p.Email,
+		Name:  p.Name,
+	}
+}
+
+// ToDomain converts PatchUserPayload to domain.PatchUserRequest
+func (p PatchUserPayload) ToDomain() domain.PatchUserRequest {
+	return domain.PatchUserRequest{
+		Email: p.Email,
+		Name:  p.Name,
+	}
+}
+
+// UserResponse is the response shape for a user
+type UserResponse struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// UserListResponse wraps the list of users
+type UserListResponse struct {
+	Items []UserResponse `json:"items"`
+}
+
+// fromDomainUser maps a domain.User to UserResponse
+func fromDomainUser(u domain.User) UserResponse {
+	return UserResponse{
+		ID:        u.ID.String(),
+		Email:     u.Email,
+		Name:      u.Name,
+		CreatedAt: u.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: u.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// fromDomainUsers maps a slice of domain.User to UserListResponse
+func fromDomainUsers(users []domain.User) UserListResponse {
+	items := make([]UserResponse, 0, len(users))
+	for _, u := range users {
+		items = append(items, fromDomainUser(u))
+	}
+	return UserListResponse{Items: items}
+}
+
+// AssignmentResponse is the response shape for one change of a task's assignee
+type AssignmentResponse struct {
+	ID                 string  `json:"id"`
+	PreviousAssigneeID *string `json:"previous_assignee_id"`
+	AssigneeID         *string `json:"assignee_id"`
+	ChangedBy          *string `json:"changed_by,omitempty"`
+	CreatedAt          string  `json:"created_at"`
+}
+
+// AssignmentListResponse wraps the reassignment history of a task
+type AssignmentListResponse struct {
+	Items []AssignmentResponse `json:"items"`
+}
+
+// fromDomainAssignments maps a task's reassignment history to AssignmentListResponse
+func fromDomainAssignments(assignments []domain.Assignment) AssignmentListResponse {
+	items := make([]AssignmentResponse, 0, len(assignments))
+	for _, a := range assignments {
+		items = append(items, AssignmentResponse{
+			ID:                 a.ID.String(),
+			PreviousAssigneeID: optionalID(a.PreviousAssigneeID),
+			AssigneeID:         optionalID(a.AssigneeID),
+			ChangedBy:          optionalID(a.ChangedBy),
+			CreatedAt:          a.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return AssignmentListResponse{Items: items}
+}
+
+// FieldChangeResponse is the response shape for the change of one task field
+type FieldChangeResponse struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// HistoryEntryResponse is the response shape for one version of a task
+type HistoryEntryResponse struct {
+	Version         int                   `json:"version"`
+	Action          string                `json:"action"`
+	RestoredVersion *int                  `json:"restored_version,omitempty"`
+	ChangedBy       *string               `json:"changed_by,omitempty"`
+	ChangedAt       string                `json:"changed_at"`
+	Changes         []FieldChangeResponse `json:"changes"`
+}
+
+// HistoryListResponse wraps the version history of a task
+type HistoryListResponse struct {
+	Items []HistoryEntryResponse `json:"items"`
+}
+
+// fromDomainHistory maps a task's version history to HistoryListResponse
+func fromDomainHistory(entries []domain.TaskHistoryEntry) HistoryListResponse {
+	items := make([]HistoryEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		changes := make([]FieldChangeResponse, 0, len(e.Changes))
+		for _, c := range e.Changes {
+			changes = append(changes, FieldChangeResponse{Field: c.Field, From: c.From, To: c.To})
+		}
+		items = append(items, HistoryEntryResponse{
+			Version:         e.Version,
+			Action:          string(e.Action),
+			RestoredVersion: e.RestoredVersion,
+			ChangedBy:       optionalID(e.ChangedBy),
+			ChangedAt:       e.ChangedAt.UTC().Format(time.RFC3339),
+			Changes:         changes,
+		})
+	}
+	return HistoryListResponse{Items: items}
+}
+
+// CreateProjectPayload represents the HTTP request body to create a project
+type CreateProjectPayload struct {
+	Key         string  `json:"key" validate:"required,projectkey"`
+	Name        string  `json:"name" validate:"required,min=1,max=100"`
+	Description *string `json:"description,omitempty"`
+}
+
+// PatchProjectPayload represents the HTTP request body to partially update a project
+type PatchProjectPayload struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description,omitempty"`
+	Archived    *bool   `json:"archived,omitempty"`
+}
+
+// ToDomain converts CreateProjectPayload to domain.CreateProjectRequest
+func (p CreateProjectPayload) ToDomain() domain.CreateProjectRequest {
+	return domain.CreateProjectRequest{
+		Key:         p.Key,
+		Name:        p.Name,
+		Description: p.Description,
+	}
+}
+
+// ToDomain converts PatchProjectPayload to domain.PatchProjectRequest
+func (p PatchProjectPayload) ToDomain() domain.PatchProjectRequest {
+	return domain.PatchProjectRequest{
+		Name:        p.Name,
+		Description: p.Description,
+		Archived:    p.Archived,
+	}
+}
+
+// ProjectResponse is the response shape for a project
+type ProjectResponse struct {
+	ID          string  `json:"id"`
+	Key         string  `json:"key"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	Archived    bool    `json:"archived"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+// ProjectListResponse wraps the list of projects
+type ProjectListResponse struct {
+	Items []ProjectResponse `json:"items"`
+}
+
+// fromDomainProject maps a domain.Project to ProjectResponse
+func fromDomainProject(p domain.Project) ProjectResponse {
+	return ProjectResponse{
+		ID:          p.ID.String(),
+		Key:         p.Key,
+		Name:        p.Name,
+		Description: p.Description,
+		Archived:    p.Archived,
+		CreatedAt:   p.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:   p.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// fromDomainProjects maps a slice of domain.Project to ProjectListResponse
+func fromDomainProjects(projects []domain.Project) ProjectListResponse {
+	items := make([]ProjectResponse, 0, len(projects))
+	for _, p := range projects {
+		items = append(items, fromDomainProject(p))
+	}
+	return ProjectListResponse{Items: items}
+}
+
+// TaskCountsResponse is the response shape for the counts of a group of tasks
+type TaskCountsResponse struct {
+	Tasks   int `json:"tasks"`
+	Done    int `json:"done"`
+	Overdue int `json:"overdue"`
+}
+
+// TaskStatsGroupResponse is the response shape for one group of task stats,
+// keyed by dimension; a null key means the tasks have no value
+type TaskStatsGroupResponse struct {
+	Key map[string]*string `json:"key"`
+	TaskCountsResponse
+}
+
+// TaskStatsResponse is the response shape for task stats
+type TaskStatsResponse struct {
+	GroupBy []string                 `json:"group_by"`
+	AsOf    string                   `json:"as_of"`
+	Total   TaskCountsResponse       `json:"total"`
+	Groups  []TaskStatsGroupResponse `json:"groups"`
+}
+
+// fromDomainTaskCounts maps domain.TaskCounts to TaskCountsResponse
+func fromDomainTaskCounts(c domain.TaskCounts) TaskCountsResponse {
+	return TaskCountsResponse{Tasks: c.Tasks, Done: c.Done, Overdue: c.Overdue}
+}
+
+// fromDomainTaskStats maps domain.TaskStats to TaskStatsResponse
+func fromDomainTaskStats(s *domain.TaskStats) TaskStatsResponse {
+	groupBy := make([]string, len(s.GroupBy))
+	for i, d := range s.GroupBy {
+		groupBy[i] = string(d)
+	}
+
+	groups := make([]TaskStatsGroupResponse, 0, len(s.Groups))
+	for _, g := range s.Groups {
+		key := make(map[string]*string, len(g.Keys))
+		for i, k := range g.Keys {
+			key[groupBy[i]] = k
+		}
+		groups = append(groups, TaskStatsGroupResponse{Key: key, TaskCountsResponse: fromDomainTaskCounts(g.TaskCounts)})
+	}
+
+	return TaskStatsResponse{
+		GroupBy: groupBy,
+		AsOf:    s.AsOf.UTC().Format(time.RFC3339),
+		Total:   fromDomainTaskCounts(s.Total),
+		Groups:  groups,
+	}
+}
+
+// CreateViewPayload represents the HTTP request body to save a view. Filter
+// takes the query parameters of GET /v1/tasks that select tasks, such as
+// status, assignee or filter.
+type CreateViewPayload struct {
+	Name    string            `json:"name" validate:"required,min=1,max=100"`
+	Filter  map[string]string `json:"filter,omitempty"`
+	Sort    string            `json:"sort,omitempty"`
+	Columns []string          `json:"columns,omitempty" validate:"max=50,dive,taskcolumn"`
+	Shared  bool              `json:"shared"`
+}
+
+// PatchViewPayload represents the HTTP request body to partially update a view
+type PatchViewPayload struct {
+	Name    *string           `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Filter  map[string]string `json:"filter,omitempty"`
+	Sort    *string           `json:"sort,omitempty"`
+	Columns []string          `json:"columns,omitempty" validate:"omitempty,max=50,dive,taskcolumn"`
+	Shared  *bool             `json:"shared,omitempty"`
+}
+
+// ToDomain converts CreateViewPayload to domain.CreateViewRequest
+func (p CreateViewPayload) ToDomain() domain.CreateViewRequest {
+	return domain.CreateViewRequest{
+		Name:    p.Name,
+		Filter:  p.Filter,
+		Sort:    p.Sort,
+		Columns: p.Columns,
+		Shared:  p.Shared,
+	}
+}
+
+// ToDomain converts PatchViewPayload to domain.PatchViewRequest
+func (p PatchViewPayload) ToDomain() domain.PatchViewRequest {
+	return domain.PatchViewRequest{
+		Name:    p.Name,
+		Filter:  p.Filter,
+		Sort:    p.Sort,
+		Columns: p.Columns,
+		Shared:  p.Shared,
+	}
+}
+
+// ViewResponse is the response shape for a saved view. URL, the address that
+// lists the view's tasks, is given for shared views; it stays the same for
+// the life of the view.
+type ViewResponse struct {
+	ID        string            `json:"id"`
+	OwnerID   string            `json:"owner_id"`
+	Name      string            `json:"name"`
+	Filter    map[string]string `json:"filter"`
+	Sort      string            `json:"sort,omitempty"`
+	Columns   []string          `json:"columns"`
+	Shared    bool              `json:"shared"`
+	URL       *string           `json:"url,omitempty"`
+	CreatedAt string            `json:"created_at"`
+	UpdatedAt string            `json:"updated_at"`
+}
+
+// ViewListResponse wraps the list of views
+type ViewListResponse struct {
+	Items []ViewResponse `json:"items"`
+}
+
+// fromDomainView maps a domain.View to ViewResponse
+func fromDomainView(v domain.View) ViewResponse {
+	var url *string
+	if v.Shared {
+		u := "/v1/views/" + v.ID.String() + "/tasks"
+		url = &u
+	}
+	return ViewResponse{
+		ID:        v.ID.String(),
+		OwnerID:   v.OwnerID.String(),
+		Name:      v.Name,
+		Filter:    v.Filter,
+		Sort:      v.Sort,
+		Columns:   v.Columns,
+		Shared:    v.Shared,
+		URL:       url,
+		CreatedAt: v.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: v.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// fromDomainViews maps a slice of domain.View to ViewListResponse
+func fromDomainViews(views []domain.View) ViewListResponse {
+	items := make([]ViewResponse, 0, len(views))
+	for _, v := range views {
+		items = append(items, fromDomainView(v))
+	}
+	return ViewListResponse{Items: items}
+}
+
+// CreateCommentPayload represents the HTTP request body to add a comment or reply
+type CreateCommentPayload struct {
+	Body     string     `json:"body" validate:"required,min=1,max=10000"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+// UpdateCommentPayload represents the HTTP request body to edit a comment
+type UpdateCommentPayload struct {
+	Body    string `json:"body" validate:"required,min=1,max=10000"`
+	Version int    `json:"version" validate:"required,min=1"`
+}
+
+// ToDomain converts CreateCommentPayload to domain.CreateCommentRequest
+func (p CreateCommentPayload) ToDomain() domain.CreateCommentRequest {
+	return domain.CreateCommentRequest{
+		Body:     p.Body,
+		ParentID: p.ParentID,
+	}
+}
+
+// ToDomain converts UpdateCommentPayload to domain.UpdateCommentRequest
+func (p UpdateCommentPayload) ToDomain() domain.UpdateCommentRequest {
+	return domain.UpdateCommentRequest{
+		Body:    p.Body,
+		Version: p.Version,
+	}
+}
+
+// CommentResponse is the response shape for a comment
+type CommentResponse struct {
+	ID        string  `json:"id"`
+	TaskID    string  `json:"task_id"`
+	ParentID  *string `json:"parent_id,omitempty"`
+	AuthorID  *string `json:"author_id,omitempty"`
+	Body      string  `json:"body"`
+	Deleted   bool    `json:"deleted"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+	Version   int     `json:"version"`
+}
+
+// CommentListResponse wraps a page of comments with the cursor of the next page
+type CommentListResponse struct {
+	Items      []CommentResponse `json:"items"`
+	NextCursor *string           `json:"next_cursor"`
+}
+
+// fromDomainComment maps a domain.Comment to CommentResponse
+func fromDomainComment(c domain.Comment) CommentResponse {
+	return CommentResponse{
+		ID:        c.ID.String(),
+		TaskID:    c.TaskID.String(),
+		ParentID:  optionalID(c.ParentID),
+		AuthorID:  optionalID(c.AuthorID),
+		Body:      c.Body,
+		Deleted:   c.DeletedAt != nil,
+		CreatedAt: c.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: c.UpdatedAt.UTC().Format(time.RFC3339),
+		Version:   c.Version,
+	}
+}
+
+// fromDomainCommentList maps a domain.CommentList to CommentListResponse
+func fromDomainCommentList(l *domain.CommentList) CommentListResponse {
+	items := make([]CommentResponse, 0, len(l.Items))
+	for _, c := range l.Items {
+		items = append(items, fromDomainComment(c))
+	}
+
+	var next *string
+	if l.Next != nil {
+		cursor := encodeCommentCursor(*l.Next)
+		next = &cursor
+	}
+
+	return CommentListResponse{Items: items, NextCursor: next}
+}
+
+// AttachmentResponse is the response shape for attachment metadata
+type AttachmentResponse struct {
+	ID             string  `json:"id"`
+	TaskID         string  `json:"task_id"`
+	Filename       string  `json:"filename"`
+	ContentType    string  `json:"content_type"`
+	Size           int64   `json:"size"`
+	ChecksumSHA256 string  `json:"checksum_sha256"`
+	UploadedBy     *string `json:"uploaded_by,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+// AttachmentListResponse wraps the attachments of a task
+type AttachmentListResponse struct {
+	Items []AttachmentResponse `json:"items"`
+}
+
+// fromDomainAttachment maps a domain.Attachment to AttachmentResponse
+func fromDomainAttachment(a domain.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:             a.ID.String(),
+		TaskID:         a.TaskID.String(),
+		Filename:       a.Filename,
+		ContentType:    a.ContentType,
+		Size:           a.Size,
+		ChecksumSHA256: a.ChecksumSHA256,
+		UploadedBy:     optionalID(a.UploadedBy),
+		CreatedAt:      a.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// fromDomainAttachments maps a slice of domain.Attachment to AttachmentListResponse
+func fromDomainAttachments(attachments []domain.Attachment) AttachmentListResponse {
+	items := make([]AttachmentResponse, 0, len(attachments))
+	for _, a := range attachments {
+		items = append(items, fromDomainAttachment(a))
+	}
+	return AttachmentListResponse{Items: items}
+}
+
+// OccurrenceResponse is one upcoming occurrence of a recurring task
+type OccurrenceResponse struct {
+	DueDate string `json:"due_date"`
+}
+
+// OccurrenceListResponse wraps the upcoming occurrences of a recurring task
+type OccurrenceListResponse struct {
+	Items []OccurrenceResponse `json:"items"`
+}
+
+// fromOccurrences maps the due dates of upcoming occurrences to OccurrenceListResponse
+func fromOccurrences(dueDates []time.Time) OccurrenceListResponse {
+	items := make([]OccurrenceResponse, 0, len(dueDates))
+	for _, d := range dueDates {
+		items = append(items, OccurrenceResponse{DueDate: d.UTC().Format(time.RFC3339)})
+	}
+	return OccurrenceListResponse{Items: items}
+}
+
+// CreateWebhookPayload represents the HTTP request body to create a webhook
+type CreateWebhookPayload struct {
+	URL    string   `json:"url" validate:"required,url,max=2000"`
+	Secret *string  `json:"secret,omitempty" validate:"omitempty,min=16,max=200"`
+	Events []string `json:"events,omitempty" validate:"omitempty,dive,min=1,max=50"`
+	Active *bool    `json:"active,omitempty"`
+}
+
+// PatchWebhookPayload represents the HTTP request body to partially update a webhook
+type PatchWebhookPayload struct {
+	URL    *string   `json:"url,omitempty" validate:"omitempty,url,max=2000"`
+	Secret *string   `json:"secret,omitempty" validate:"omitempty,min=16,max=200"`
+	Events *[]string `json:"events,omitempty" validate:"omitempty,dive,min=1,max=50"`
+	Active *bool     `json:"active,omitempty"`
+}
+
+// ToDomain converts CreateWebhookPayload to domain.CreateWebhookRequest
+func (p CreateWebhookPayload) ToDomain() domain.CreateWebhookRequest {
+	return domain.CreateWebhookRequest{
+		URL:    p.URL,
+		Secret: p.Secret,
+		Events: toEventTypes(p.Events),
+		Active: p.Active,
+	}
+}
+
+// ToDomain converts PatchWebhookPayload to domain.PatchWebhookRequest
+func (p PatchWebhookPayload) ToDomain() domain.PatchWebhookRequest {
+	req := domain.PatchWebhookRequest{
+		URL:    p.URL,
+		Secret: p.Secret,
+		Active: p.Active,
+	}
+	if p.Events != nil {
+		events := toEventTypes(*p.Events)
+		req.Events = &events
+	}
+	return req
+}
+
+// toEventTypes converts event type names to domain.TaskEventType values
+func toEventTypes(names []string) []domain.TaskEventType {
+	events := make([]domain.TaskEventType, len(names))
+	for i, name := range names {
+		events[i] = domain.TaskEventType(name)
+	}
+	return events
+}
+
+// WebhookResponse is the response shape for a webhook. The secret is only
+// returned when the webhook is created.
+type WebhookResponse struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Secret    string   `json:"secret,omitempty"`
+	Events    []string `json:"events"`
+	Active    bool     `json:"active"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+// WebhookListResponse wraps the list of webhooks
+type WebhookListResponse struct {
+	Items []WebhookResponse `json:"items"`
+}
+
+// fromDomainWebhook maps a domain.Webhook to WebhookResponse
+func fromDomainWebhook(w domain.Webhook) WebhookResponse {
+	events := make([]string, len(w.Events))
+	for i, e := range w.Events {
+		events[i] = string(e)
+	}
+	return WebhookResponse{
+		ID:        w.ID.String(),
+		URL:       w.URL,
+		Events:    events,
+		Active:    w.Active,
+		CreatedAt: w.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: w.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// fromDomainWebhooks maps a slice of domain.Webhook to WebhookListResponse
+func fromDomainWebhooks(webhooks []domain.Webhook) WebhookListResponse {
+	items := make([]WebhookResponse, 0, len(webhooks))
+	for _, w := range webhooks {
+		items = append(items, fromDomainWebhook(w))
+	}
+	return WebhookListResponse{Items: items}
+}
+
+// DeliveryResponse is the response shape for a webhook delivery
+type DeliveryResponse struct {
+	ID             string          `json:"id"`
+	WebhookID      string          `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *string         `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	LastError      *string         `json:"last_error,omitempty"`
+	DeliveredAt    *string         `json:"delivered_at,omitempty"`
+	CreatedAt      string          `json:"created_at"`
+	UpdatedAt      string          `json:"updated_at"`
+}
+
+// DeliveryListResponse wraps the list of webhook deliveries
+type DeliveryListResponse struct {
+	Items []DeliveryResponse `json:"items"`
+}
+
+// fromDomainDelivery maps a domain.WebhookDelivery to DeliveryResponse. The
+// next attempt is only reported while the delivery is pending.
+func fromDomainDelivery(d domain.WebhookDelivery) DeliveryResponse {
+	var nextAttempt *time.Time
+	if d.Status == domain.DeliveryPending {
+		nextAttempt = &d.NextAttemptAt
+	}
+	return DeliveryResponse{
+		ID:             d.ID.String(),
+		WebhookID:      d.WebhookID.String(),
+		EventID:        d.EventID.String(),
+		EventType:      string(d.EventType),
+		Payload:        d.Payload,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  optionalTime(nextAttempt),
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    optionalTime(d.DeliveredAt),
+		CreatedAt:      d.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:      d.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// fromDomainDeliveries maps a slice of domain.WebhookDelivery to DeliveryListResponse
+func fromDomainDeliveries(deliveries []domain.WebhookDelivery) DeliveryListResponse {
+	items := make([]DeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		items = append(items, fromDomainDelivery(d))
+	}
+	return DeliveryListResponse{Items: items}
+}
+
 // no custom time layout constant; using time.RFC3339
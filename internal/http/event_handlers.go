@@ -0,0 +1,128 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"task-svc/internal/config"
+	"task-svc/internal/domain"
+	"task-svc/internal/service"
+)
+
+// streamRetry is the reconnection delay suggested to event stream clients
+const streamRetry = 3 * time.Second
+
+// EventHandler handles HTTP requests for the live task event stream
+type EventHandler struct {
+	responder
+	stream    service.EventStream
+	heartbeat time.Duration
+}
+
+// NewEventHandler creates a new event handler
+func NewEventHandler(stream service.EventStream, cfg config.EventStreamConfig, logger *slog.Logger) *EventHandler {
+	return &EventHandler{
+		responder: responder{logger: logger},
+		stream:    stream,
+		heartbeat: max(cfg.Heartbeat, time.Second),
+	}
+}
+
+// Stream handles GET /v1/events/stream, sending task events as Server-Sent
+// Events until the client disconnects. Each event's SSE id is its outbox
+// seq; a client resuming with Last-Event-ID first receives the events it
+// missed, or a reset event when they are no longer buffered and it should
+// reload its state. A client that cannot keep up is disconnected and may
+// resume the same way.
+func (h *EventHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	var filter domain.EventFilter
+	for _, name := range splitList(r.URL.Query().Get("status")) {
+		filter.Statuses = append(filter.Statuses, domain.Status(name))
+	}
+	if projectParam := r.URL.Query().Get("project_id"); projectParam != "" {
+		id, err := uuid.Parse(projectParam)
+		if err != nil {
+			h.respondWithError(w, http.StatusBadRequest, "invalid_project_id", "Invalid project ID", nil)
+			return
+		}
+		filter.ProjectID = &id
+	}
+
+	// Browsers send Last-Event-ID on reconnects only, so it may also be given
+	// as a query parameter when a page is reloaded
+	var lastEventID int64
+	lastParam := r.Header.Get("Last-Event-ID")
+	if lastParam == "" {
+		lastParam = r.URL.Query().Get("last_event_id")
+	}
+	if lastParam != "" {
+		id, err := strconv.ParseInt(lastParam, 10, 64)
+		if err != nil || id < 0 {
+			h.respondWithError(w, http.StatusBadRequest, "invalid_last_event_id", "Invalid last event ID", nil)
+			return
+		}
+		lastEventID = id
+	}
+
+	sub, resumed, err := h.stream.Subscribe(r.Context(), filter, lastEventID)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to subscribe to events")
+		return
+	}
+	defer sub.Close()
+
+	// The stream outlives the server's write timeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+	if !resumed {
+		fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-sub.Events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				h.logger.Error("Failed to encode task event", "seq", event.Seq, "error", err)
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, data)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// RegisterRoutes registers the event routes
+func (h *EventHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/events/stream", h.Stream)
+}
@@ -1,10 +1,16 @@
 package http
 
 import (
+	"context"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"log/slog"
 
@@ -14,53 +20,48 @@ import (
 	"task-svc/internal/config"
 	"task-svc/internal/domain"
 	"task-svc/internal/service"
+	"task-svc/pkg/filter"
 	"task-svc/pkg/pagination"
 )
 
+// TaskSubresource is implemented by handlers that serve routes below /tasks/{id}.
+// They share the task routes' scoping, including the per-project mount.
+type TaskSubresource interface {
+	RegisterTaskRoutes(r chi.Router)
+}
+
 // TaskHandler handles HTTP requests for tasks
 type TaskHandler struct {
+	responder
 	service          service.TaskService
 	paginationConfig config.PaginationConfig
-	logger           *slog.Logger
+	cursors          *pagination.Signer
+	subresources     []TaskSubresource
 }
 
 // NewTaskHandler creates a new task handler
 func NewTaskHandler(service service.TaskService, cfg config.PaginationConfig, logger *slog.Logger) *TaskHandler {
+	secret := []byte(cfg.CursorSecret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic(fmt.Sprintf("generating cursor secret: %v", err))
+		}
+		logger.Warn("PAGINATION_CURSOR_SECRET is not set; task list cursors only work on this instance until it restarts")
+	}
+
 	return &TaskHandler{
+		responder:        responder{logger: logger},
 		service:          service,
 		paginationConfig: cfg,
-		logger:           logger,
+		cursors:          pagination.NewSigner(secret),
 	}
 }
 
-// errorResponse represents an error response
-type errorResponse struct {
-	Error struct {
-		Code    string                 `json:"code"`
-		Message string                 `json:"message"`
-		Details map[string]interface{} `json:"details,omitempty"`
-	} `json:"error"`
-}
-
-// respondWithJSON sends a JSON response
-func (h *TaskHandler) respondWithJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	if data != nil {
-		if err := json.NewEncoder(w).Encode(data); err != nil {
-			h.logger.Error("Error encoding response", "error", err)
-		}
-	}
-}
-
-// respondWithError sends an error response
-func (h *TaskHandler) respondWithError(w http.ResponseWriter, status int, code, message string, details map[string]interface{}) {
-	resp := errorResponse{}
-	resp.Error.Code = code
-	resp.Error.Message = message
-	resp.Error.Details = details
-
-	h.respondWithJSON(w, status, resp)
+// AddSubresource mounts the routes of sub below /tasks/{id}. It must be called
+// before RegisterRoutes.
+func (h *TaskHandler) AddSubresource(sub TaskSubresource) {
+	h.subresources = append(h.subresources, sub)
 }
 
 // CreateTask handles POST /v1/tasks
@@ -77,26 +78,25 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Tasks created below /projects/{pid} always land in that project
+	if project, ok := projectFromContext(r.Context()); ok {
+		payload.ProjectID = &project.ID
+	}
+
 	task, err := h.service.Create(r.Context(), payload.ToDomain())
 	if err != nil {
-		h.logger.Error("Failed to create task", "error", err)
-		h.respondWithError(w, http.StatusInternalServerError, "internal_error", "Failed to create task", nil)
+		h.respondWithServiceError(w, err, "Failed to create task")
 		return
 	}
 
 	h.respondWithJSON(w, http.StatusCreated, fromDomainTask(*task))
 }
 
-// GetTask handles GET /v1/tasks/{id}
+// GetTask handles GET /v1/tasks/{id}, where id is a task ID or a key such as BILL-123
 func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
-	idParam := chi.URLParam(r, "id")
-	id, err := uuid.Parse(idParam)
-	if err != nil {
-		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
-		return
-	}
+	includeDeleted := r.URL.Query().Get("include_deleted") == "true"
 
-	task, err := h.service.Get(r.Context(), id)
+	task, err := h.findTask(r.Context(), chi.URLParam(r, "id"), includeDeleted)
 	if err != nil {
 		if errors.Is(err, service.ErrNotFound) {
 			h.respondWithError(w, http.StatusNotFound, "not_found", "Task not found", nil)
@@ -112,6 +112,87 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 
 // ListTasks handles GET /v1/tasks
 func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	filter, ok := h.parseTaskFilter(w, r, "-created_at")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.List(r.Context(), filter)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list tasks")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, h.taskListResponse(result, filter.Sort))
+}
+
+// SearchTasks handles GET /v1/tasks/search. It takes the same filters and
+// pagination as ListTasks and requires q; results are ranked by relevance
+// and paged by number only.
+func (h *TaskHandler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	filter, ok := h.parseTaskFilter(w, r, "-created_at")
+	if !ok {
+		return
+	}
+	if filter.After != nil || filter.Before != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_cursor", "Search results are paged by page number", nil)
+		return
+	}
+
+	result, err := h.service.Search(r.Context(), filter)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to search tasks")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainSearchResults(result))
+}
+
+// ListChildren handles GET /v1/tasks/{id}/children
+func (h *TaskHandler) ListChildren(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
+		return
+	}
+
+	filter, ok := h.parseTaskFilter(w, r, "-created_at")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.ListChildren(r.Context(), id, filter)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list subtasks")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, h.taskListResponse(result, filter.Sort))
+}
+
+// GetTree handles GET /v1/tasks/{id}/tree
+func (h *TaskHandler) GetTree(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
+		return
+	}
+
+	tree, err := h.service.Tree(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to retrieve task tree")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainTaskTree(*tree))
+}
+
+// parseTaskFilter reads the list query parameters shared by task listing endpoints,
+// sorting by defaultSort unless asked otherwise. It writes an error response and
+// returns false when a parameter is invalid.
+func (h *TaskHandler) parseTaskFilter(w http.ResponseWriter, r *http.Request, defaultSort string) (domain.TaskFilter, bool) {
 	// Parse pagination parameters
 	pageNum, pageSize := pagination.Parse(
 		r.URL.Query().Get("page"),
@@ -123,42 +204,380 @@ func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 		},
 	)
 
-	// Parse status filter
+	// Parse status filter; the service checks it against the live status set
 	var status *domain.Status
 	if statusParam := r.URL.Query().Get("status"); statusParam != "" {
 		s := domain.Status(statusParam)
-		if !isValidStatus(s) {
-			h.respondWithError(w, http.StatusBadRequest, "invalid_status", "Invalid status value", nil)
-			return
-		}
 		status = &s
 	}
 
+	// Parse priority filter; it takes a comma-separated list of priorities
+	var priorities []domain.Priority
+	for _, name := range splitList(r.URL.Query().Get("priority")) {
+		p := domain.Priority(name)
+		if !p.Valid() {
+			h.respondWithError(w, http.StatusBadRequest, "invalid_priority", "Invalid priority value", nil)
+			return domain.TaskFilter{}, false
+		}
+		priorities = append(priorities, p)
+	}
+
+	// Parse severity filter; it takes a comma-separated list of severities
+	var severities []domain.Severity
+	for _, name := range splitList(r.URL.Query().Get("severity")) {
+		s := domain.Severity(name)
+		if !s.Valid() {
+			h.respondWithError(w, http.StatusBadRequest, "invalid_severity", "Invalid severity value", nil)
+			return domain.TaskFilter{}, false
+		}
+		severities = append(severities, s)
+	}
+
+	// Parse parent filter
+	var parentID *uuid.UUID
+	if parentParam := r.URL.Query().Get("parent_id"); parentParam != "" {
+		id, err := uuid.Parse(parentParam)
+		if err != nil {
+			h.respondWithError(w, http.StatusBadRequest, "invalid_parent_id", "Invalid parent task ID", nil)
+			return domain.TaskFilter{}, false
+		}
+		parentID = &id
+	}
+
+	// Restrict to the project the request is scoped to
+	var projectID *uuid.UUID
+	if project, ok := projectFromContext(r.Context()); ok {
+		projectID = &project.ID
+	}
+
+	// Parse assignee filter: me, unassigned or a user ID
+	var assigneeID *uuid.UUID
+	var unassigned bool
+	switch assigneeParam := r.URL.Query().Get("assignee"); assigneeParam {
+	case "":
+	case "unassigned":
+		unassigned = true
+	case "me":
+		actor, ok := domain.ActorFromContext(r.Context())
+		if !ok {
+			h.respondWithError(w, http.StatusUnauthorized, "unauthenticated", "assignee=me requires the "+UserIDHeader+" header", nil)
+			return domain.TaskFilter{}, false
+		}
+		assigneeID = &actor
+	default:
+		id, err := uuid.Parse(assigneeParam)
+		if err != nil {
+			h.respondWithError(w, http.StatusBadRequest, "invalid_assignee", "assignee must be me, unassigned or a user ID", nil)
+			return domain.TaskFilter{}, false
+		}
+		assigneeID = &id
+	}
+
+	// Parse label filters; each takes a comma-separated list of label names
+	labelsAny := splitList(r.URL.Query().Get("labels_any"))
+	labelsAll := splitList(r.URL.Query().Get("labels_all"))
+	labelsNone := splitList(r.URL.Query().Get("labels_none"))
+
+	// Parse the filter expression; errors point at the offending token
+	var where filter.Node
+	if expr := r.URL.Query().Get("filter"); expr != "" {
+		node, err := filter.Parse(expr, domain.TaskFilterFields, time.Now().UTC())
+		if err != nil {
+			var details map[string]interface{}
+			var parseErr *filter.Error
+			if errors.As(err, &parseErr) {
+				details = map[string]interface{}{"position": parseErr.Pos, "token": parseErr.Token}
+			}
+			h.respondWithError(w, http.StatusBadRequest, "invalid_filter", err.Error(), details)
+			return domain.TaskFilter{}, false
+		}
+		where = node
+	}
+
+	// Parse full-text search
+	var search *domain.SearchQuery
+	if q := r.URL.Query().Get("q"); q != "" {
+		parsed, ok := domain.ParseSearchQuery(q)
+		if !ok {
+			h.respondWithError(w, http.StatusBadRequest, "invalid_query", "Search query has no words to search for", nil)
+			return domain.TaskFilter{}, false
+		}
+		search = &parsed
+	}
+
+	// Tasks in the trash are left out unless asked for, and so are archived
+	// tasks; archived=true lists only the archived ones
+	includeDeleted := r.URL.Query().Get("include_deleted") == "true"
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+	archived := r.URL.Query().Get("archived") == "true"
+
 	// Parse sort parameter
-	sort := r.URL.Query().Get("sort")
-	if sort == "" {
-		sort = "-created_at" // Default sort
+	sortParam := r.URL.Query().Get("sort")
+	if sortParam == "" {
+		sortParam = defaultSort
 	}
-	if !isValidSortField(sort) {
+	sort, ok := parseSort(sortParam)
+	if !ok {
 		h.respondWithError(w, http.StatusBadRequest, "invalid_sort", "Invalid sort parameter", nil)
+		return domain.TaskFilter{}, false
+	}
+
+	// A cursor selects the tasks after or before it instead of a page number
+	var cursors [2]*domain.TaskCursor
+	for i, param := range []string{"after", "before"} {
+		value := r.URL.Query().Get(param)
+		if value == "" {
+			continue
+		}
+		cursor, err := h.decodeTaskCursor(value, sort)
+		if err != nil {
+			h.respondWithError(w, http.StatusBadRequest, "invalid_cursor", "Invalid cursor, or one issued for another sort", nil)
+			return domain.TaskFilter{}, false
+		}
+		cursors[i] = cursor
+	}
+	after, before := cursors[0], cursors[1]
+	if after != nil && before != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_cursor", "Use either after or before", nil)
+		return domain.TaskFilter{}, false
+	}
+
+	// Totals are counted for pages selected by number unless declined, and
+	// for pages selected by cursor only when asked for
+	countTotal := after == nil && before == nil
+	switch r.URL.Query().Get("include_total") {
+	case "true":
+		countTotal = true
+	case "false":
+		countTotal = false
+	}
+
+	return domain.TaskFilter{
+		Status:          status,
+		Priorities:      priorities,
+		Severities:      severities,
+		ParentID:        parentID,
+		ProjectID:       projectID,
+		AssigneeID:      assigneeID,
+		Unassigned:      unassigned,
+		LabelsAny:       labelsAny,
+		LabelsAll:       labelsAll,
+		LabelsNone:      labelsNone,
+		Where:           where,
+		Search:          search,
+		IncludeDeleted:  includeDeleted,
+		IncludeArchived: includeArchived,
+		Archived:        archived,
+		Page:            pageNum,
+		Size:            pageSize,
+		After:           after,
+		Before:          before,
+		CountTotal:      countTotal,
+		Sort:            sort,
+	}, true
+}
+
+// taskListResponse maps a page of tasks to its response, with the cursors of
+// the neighbouring pages
+func (h *TaskHandler) taskListResponse(l *domain.TaskList, sort []domain.SortField) TaskListResponse {
+	resp := fromDomainTaskList(l)
+	if l.Next != nil {
+		next := h.encodeTaskCursor(sort, *l.Next)
+		resp.NextCursor = &next
+	}
+	if l.Prev != nil {
+		prev := h.encodeTaskCursor(sort, *l.Prev)
+		resp.PrevCursor = &prev
+	}
+	return resp
+}
+
+// encodeTaskCursor turns a position in a task list into a signed cursor,
+// bound to the list's sort
+func (h *TaskHandler) encodeTaskCursor(sort []domain.SortField, c domain.TaskCursor) string {
+	values := make([]string, 0, len(c.Keys)+3)
+	values = append(values, formatSort(sort))
+	for _, key := range c.Keys {
+		switch k := key.(type) {
+		case time.Time:
+			values = append(values, k.UTC().Format(time.RFC3339Nano))
+		case int:
+			values = append(values, strconv.Itoa(k))
+		default:
+			values = append(values, "")
+		}
+	}
+	values = append(values, c.CreatedAt.UTC().Format(time.RFC3339Nano), c.ID.String())
+	return h.cursors.EncodeCursor(values...)
+}
+
+// decodeTaskCursor reads a cursor produced by encodeTaskCursor for the same sort
+func (h *TaskHandler) decodeTaskCursor(cursor string, sort []domain.SortField) (*domain.TaskCursor, error) {
+	values, err := h.cursors.DecodeCursor(cursor, len(sort)+3)
+	if err != nil {
+		return nil, err
+	}
+	if values[0] != formatSort(sort) {
+		return nil, pagination.ErrInvalidCursor
+	}
+
+	c := &domain.TaskCursor{Keys: make([]any, len(sort))}
+	for i, s := range sort {
+		value := values[i+1]
+		switch {
+		case value == "":
+		case s.Field == "priority" || s.Field == "severity":
+			rank, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, pagination.ErrInvalidCursor
+			}
+			c.Keys[i] = rank
+		default:
+			t, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return nil, pagination.ErrInvalidCursor
+			}
+			c.Keys[i] = t
+		}
+	}
+
+	if c.CreatedAt, err = time.Parse(time.RFC3339Nano, values[len(values)-2]); err != nil {
+		return nil, pagination.ErrInvalidCursor
+	}
+	if c.ID, err = uuid.Parse(values[len(values)-1]); err != nil {
+		return nil, pagination.ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// ListAssignments handles GET /v1/tasks/{id}/assignments
+func (h *TaskHandler) ListAssignments(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
 		return
 	}
 
-	filter := domain.TaskFilter{
-		Status: status,
-		Page:   pageNum,
-		Size:   pageSize,
-		Sort:   sort,
+	assignments, err := h.service.Assignments(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list assignments")
+		return
 	}
 
-	result, err := h.service.List(r.Context(), filter)
+	h.respondWithJSON(w, http.StatusOK, fromDomainAssignments(assignments))
+}
+
+// ListHistory handles GET /v1/tasks/{id}/history
+func (h *TaskHandler) ListHistory(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
+		return
+	}
+
+	entries, err := h.service.History(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list task history")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainHistory(entries))
+}
+
+// GetVersion handles GET /v1/tasks/{id}/versions/{n}
+func (h *TaskHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
+		return
+	}
+
+	version, err := strconv.Atoi(chi.URLParam(r, "n"))
+	if err != nil || version < 1 {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_version", "Version must be a positive integer", nil)
+		return
+	}
+
+	task, err := h.service.Version(r.Context(), id, version)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to get task version")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainTask(*task))
+}
+
+// RevertTask handles POST /v1/tasks/{id}/revert
+func (h *TaskHandler) RevertTask(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
+		return
+	}
+
+	var payload RevertTaskPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+
+	// Check for version in header if not in body
+	if payload.Version == 0 {
+		if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+			version, err := strconv.Atoi(ifMatch)
+			if err == nil && version > 0 {
+				payload.Version = version
+			}
+		}
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	task, err := h.service.Revert(r.Context(), id, payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to revert task")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainTask(*task))
+}
+
+// Bounds for the number of occurrences previewed for a recurring task
+const (
+	defaultOccurrencePreview = 5
+	maxOccurrencePreview     = 50
+)
+
+// PreviewOccurrences handles GET /v1/tasks/{id}/occurrences
+func (h *TaskHandler) PreviewOccurrences(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
 	if err != nil {
-		h.logger.Error("Failed to list tasks", "error", err)
-		h.respondWithError(w, http.StatusInternalServerError, "internal_error", "Failed to list tasks", nil)
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
 		return
 	}
 
-	h.respondWithJSON(w, http.StatusOK, fromDomainTaskList(result))
+	_, count := pagination.Parse("", r.URL.Query().Get("count"), pagination.DefaultParams{
+		DefaultPage: 1,
+		DefaultSize: defaultOccurrencePreview,
+		MaxSize:     maxOccurrencePreview,
+	})
+
+	dueDates, err := h.service.Occurrences(r.Context(), id, count)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to preview occurrences")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromOccurrences(dueDates))
 }
 
 // UpdateTask handles PUT /v1/tasks/{id}
@@ -195,16 +614,7 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 
 	task, err := h.service.Update(r.Context(), id, payload.ToDomain())
 	if err != nil {
-		if errors.Is(err, service.ErrNotFound) {
-			h.respondWithError(w, http.StatusNotFound, "not_found", "Task not found", nil)
-			return
-		}
-		if errors.Is(err, service.ErrVersionConflict) {
-			h.respondWithError(w, http.StatusConflict, "version_conflict", "Task was modified by another request", nil)
-			return
-		}
-		h.logger.Error("Failed to update task", "error", err)
-		h.respondWithError(w, http.StatusInternalServerError, "internal_error", "Failed to update task", nil)
+		h.respondWithServiceError(w, err, "Failed to update task")
 		return
 	}
 
@@ -245,16 +655,7 @@ func (h *TaskHandler) PatchTask(w http.ResponseWriter, r *http.Request) {
 
 	task, err := h.service.Patch(r.Context(), id, payload.ToDomain())
 	if err != nil {
-		if errors.Is(err, service.ErrNotFound) {
-			h.respondWithError(w, http.StatusNotFound, "not_found", "Task not found", nil)
-			return
-		}
-		if errors.Is(err, service.ErrVersionConflict) {
-			h.respondWithError(w, http.StatusConflict, "version_conflict", "Task was modified by another request", nil)
-			return
-		}
-		h.logger.Error("Failed to patch task", "error", err)
-		h.respondWithError(w, http.StatusInternalServerError, "internal_error", "Failed to patch task", nil)
+		h.respondWithServiceError(w, err, "Failed to patch task")
 		return
 	}
 
@@ -270,52 +671,260 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.Delete(r.Context(), id); err != nil {
-		h.logger.Error("Failed to delete task", "error", err)
-		h.respondWithError(w, http.StatusInternalServerError, "internal_error", "Failed to delete task", nil)
+	children := domain.ChildPolicy(r.URL.Query().Get("children"))
+	if children == "" {
+		children = domain.ChildPolicyReparent
+	}
+	if children != domain.ChildPolicyReparent && children != domain.ChildPolicyCascade {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_children", "children must be reparent or cascade", nil)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id, children); err != nil {
+		h.respondWithServiceError(w, err, "Failed to delete task")
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// isValidStatus checks if the status is valid
-func isValidStatus(status domain.Status) bool {
-	validStatuses := []domain.Status{
-		domain.StatusPending,
-		domain.StatusInProgress,
-		domain.StatusCompleted,
-		domain.StatusCancelled,
+// RestoreTask handles POST /v1/tasks/{id}/restore
+func (h *TaskHandler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
+		return
+	}
+
+	task, err := h.service.Restore(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to restore task")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainTask(*task))
+}
+
+// ArchiveTask handles POST /v1/tasks/{id}/archive
+func (h *TaskHandler) ArchiveTask(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
+		return
+	}
+
+	task, err := h.service.Archive(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to archive task")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainTask(*task))
+}
+
+// UnarchiveTask handles POST /v1/tasks/{id}/unarchive
+func (h *TaskHandler) UnarchiveTask(w http.ResponseWriter, r *http.Request) {
+	idParam := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid task ID", nil)
+		return
+	}
+
+	task, err := h.service.Unarchive(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to unarchive task")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainTask(*task))
+}
+
+// ArchiveTasks handles POST /v1/tasks/archive, archiving every task in a
+// done-category status completed before the given time. Under a project
+// only that project's tasks are archived.
+func (h *TaskHandler) ArchiveTasks(w http.ResponseWriter, r *http.Request) {
+	var payload ArchiveTasksPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	if project, ok := projectFromContext(r.Context()); ok {
+		payload.ProjectID = &project.ID
+	}
+
+	archived, err := h.service.ArchiveCompleted(r.Context(), payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to archive tasks")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, ArchiveTasksResponse{Archived: archived})
+}
+
+// ListTrash handles GET /v1/trash. It takes the same filters as ListTasks and
+// lists the most recently deleted tasks first unless sorted otherwise.
+func (h *TaskHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	filter, ok := h.parseTaskFilter(w, r, "-deleted_at")
+	if !ok {
+		return
 	}
 
-	for _, s := range validStatuses {
-		if status == s {
-			return true
+	result, err := h.service.Trash(r.Context(), filter)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list deleted tasks")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, h.taskListResponse(result, filter.Sort))
+}
+
+// GetWorkflow handles GET /v1/workflow. Below /projects/{pid} it covers the
+// statuses available to the project.
+func (h *TaskHandler) GetWorkflow(w http.ResponseWriter, r *http.Request) {
+	var projectID *uuid.UUID
+	if project, ok := projectFromContext(r.Context()); ok {
+		projectID = &project.ID
+	}
+
+	graph, err := h.service.Workflow(r.Context(), projectID)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to load workflow")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainWorkflow(graph))
+}
+
+// splitList splits a comma-separated query value, dropping empty entries
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
 		}
 	}
-	return false
+	return items
 }
 
-// isValidSortField checks if the sort field is valid
-func isValidSortField(sort string) bool {
-	validSortFields := []string{"created_at", "-created_at", "due_date", "-due_date"}
-	for _, s := range validSortFields {
-		if sort == s {
-			return true
+// findTask looks a task up by ID or, failing that, by its key. Tasks in the
+// trash are found only with includeDeleted.
+func (h *TaskHandler) findTask(ctx context.Context, idOrKey string, includeDeleted bool) (*domain.Task, error) {
+	if id, err := uuid.Parse(idOrKey); err == nil {
+		if includeDeleted {
+			return h.service.GetIncludingDeleted(ctx, id)
 		}
+		return h.service.Get(ctx, id)
 	}
-	return false
+	if includeDeleted {
+		return h.service.GetByKeyIncludingDeleted(ctx, idOrKey)
+	}
+	return h.service.GetByKey(ctx, idOrKey)
 }
 
-// RegisterRoutes registers all task routes
+// requireProjectTask stops requests scoped to a project from reaching tasks
+// of other projects. Unscoped requests pass through untouched.
+func (h *TaskHandler) requireProjectTask(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		project, ok := projectFromContext(r.Context())
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Tasks in the trash belong to the project as well, so they can be restored
+		task, err := h.findTask(r.Context(), chi.URLParam(r, "id"), true)
+		if err != nil {
+			h.respondWithServiceError(w, err, "Failed to retrieve task")
+			return
+		}
+		if task.ProjectID == nil || *task.ProjectID != project.ID {
+			h.respondWithError(w, http.StatusNotFound, "not_found", "Task not found", nil)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// parseSort reads a comma-separated list of sort fields, each optionally
+// prefixed with "-" for descending order, as in "priority,-due_date". It
+// reports false for unknown or repeated fields.
+func parseSort(sort string) ([]domain.SortField, bool) {
+	validSortFields := []string{"created_at", "due_date", "priority", "severity", "deleted_at"}
+
+	fields := []domain.SortField{}
+	seen := map[string]bool{}
+	for _, term := range strings.Split(sort, ",") {
+		term = strings.TrimSpace(term)
+		field := domain.SortField{Field: strings.TrimPrefix(term, "-"), Desc: strings.HasPrefix(term, "-")}
+		if seen[field.Field] || !slices.Contains(validSortFields, field.Field) {
+			return nil, false
+		}
+		seen[field.Field] = true
+		fields = append(fields, field)
+	}
+	return fields, true
+}
+
+// formatSort writes sort fields in the form parseSort reads
+func formatSort(sort []domain.SortField) string {
+	terms := make([]string, len(sort))
+	for i, s := range sort {
+		terms[i] = s.Field
+		if s.Desc {
+			terms[i] = "-" + s.Field
+		}
+	}
+	return strings.Join(terms, ",")
+}
+
+// RegisterRoutes registers all task routes. The same routes are mounted below
+// /projects/{pid}, where they only see tasks of that project.
 func (h *TaskHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/trash", h.ListTrash)
+	r.Get("/workflow", h.GetWorkflow)
 	r.Route("/tasks", func(r chi.Router) {
 		r.Post("/", h.CreateTask)
 		r.Get("/", h.ListTasks)
-		r.Get("/{id}", h.GetTask)
-		r.Put("/{id}", h.UpdateTask)
-		r.Patch("/{id}", h.PatchTask)
-		r.Delete("/{id}", h.DeleteTask)
+		r.Get("/search", h.SearchTasks)
+		r.Get("/stats", h.TaskStats)
+		r.Post("/archive", h.ArchiveTasks)
+		r.Group(func(r chi.Router) {
+			r.Use(h.requireProjectTask)
+			r.Get("/{id}", h.GetTask)
+			r.Get("/{id}/children", h.ListChildren)
+			r.Get("/{id}/tree", h.GetTree)
+			r.Get("/{id}/assignments", h.ListAssignments)
+			r.Get("/{id}/history", h.ListHistory)
+			r.Get("/{id}/versions/{n}", h.GetVersion)
+			r.Post("/{id}/revert", h.RevertTask)
+			r.Get("/{id}/occurrences", h.PreviewOccurrences)
+			r.Get("/{id}/dependencies", h.ListDependencies)
+			r.Post("/{id}/dependencies", h.AddDependency)
+			r.Delete("/{id}/dependencies/{blockerId}", h.RemoveDependency)
+			r.Put("/{id}", h.UpdateTask)
+			r.Patch("/{id}", h.PatchTask)
+			r.Delete("/{id}", h.DeleteTask)
+			r.Post("/{id}/restore", h.RestoreTask)
+			r.Post("/{id}/archive", h.ArchiveTask)
+			r.Post("/{id}/unarchive", h.UnarchiveTask)
+			for _, sub := range h.subresources {
+				sub.RegisterTaskRoutes(r)
+			}
+		})
 	})
 }
 
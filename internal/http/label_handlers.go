@@ -0,0 +1,133 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"task-svc/internal/service"
+)
+
+// LabelHandler handles HTTP requests for the task labels
+type LabelHandler struct {
+	responder
+	service service.LabelService
+}
+
+// NewLabelHandler creates a new label handler
+func NewLabelHandler(service service.LabelService, logger *slog.Logger) *LabelHandler {
+	return &LabelHandler{
+		responder: responder{logger: logger},
+		service:   service,
+	}
+}
+
+// ListLabels handles GET /v1/labels
+func (h *LabelHandler) ListLabels(w http.ResponseWriter, r *http.Request) {
+	labels, err := h.service.List(r.Context())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list labels")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainLabels(labels))
+}
+
+// CreateLabel handles POST /v1/labels
+func (h *LabelHandler) CreateLabel(w http.ResponseWriter, r *http.Request) {
+	var payload CreateLabelPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	label, err := h.service.Create(r.Context(), payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to create label")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, fromDomainLabel(*label))
+}
+
+// GetLabel handles GET /v1/labels/{id}
+func (h *LabelHandler) GetLabel(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid label ID", nil)
+		return
+	}
+
+	label, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to retrieve label")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainLabel(*label))
+}
+
+// PatchLabel handles PATCH /v1/labels/{id}
+func (h *LabelHandler) PatchLabel(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid label ID", nil)
+		return
+	}
+
+	var payload PatchLabelPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	label, err := h.service.Patch(r.Context(), id, payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to update label")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainLabel(*label))
+}
+
+// DeleteLabel handles DELETE /v1/labels/{id}
+func (h *LabelHandler) DeleteLabel(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid label ID", nil)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		h.respondWithServiceError(w, err, "Failed to delete label")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterRoutes registers all label routes
+func (h *LabelHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/labels", func(r chi.Router) {
+		r.Get("/", h.ListLabels)
+		r.Post("/", h.CreateLabel)
+		r.Get("/{id}", h.GetLabel)
+		r.Patch("/{id}", h.PatchLabel)
+		r.Delete("/{id}", h.DeleteLabel)
+	})
+}
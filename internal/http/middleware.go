@@ -3,17 +3,25 @@ package http
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 	"log/slog"
+
+	"task-svc/internal/domain"
 )
 
 // RequestIDKey is the context key for the request ID
 type contextKey string
 const RequestIDKey = contextKey("requestID")
 
+// UserIDHeader carries the ID of the calling user. It is expected to be set
+// by the authenticating gateway in front of the service.
+const UserIDHeader = "X-User-ID"
+
 // Middleware sets up all middlewares for the API
 func Middleware(r chi.Router, logger *slog.Logger) {
 	// Basic middleware
@@ -21,7 +29,8 @@ func Middleware(r chi.Router, logger *slog.Logger) {
 	r.Use(middleware.RealIP)
 	r.Use(requestLogger(logger))
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(identifyUser(logger))
+	r.Use(timeout(60 * time.Second))
     // Normalize paths (avoid trailing slash 404s)
     r.Use(middleware.StripSlashes)
 
@@ -29,6 +38,22 @@ func Middleware(r chi.Router, logger *slog.Logger) {
 	r.Use(corsMiddleware)
 }
 
+// timeout cancels the context of a request after d. Streaming endpoints,
+// whose paths end in /stream, and WebSocket upgrades are exempt: they stay
+// open until the client disconnects.
+func timeout(d time.Duration) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		limited := middleware.Timeout(d)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/stream") || strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			limited.ServeHTTP(w, r)
+		})
+	}
+}
+
 // requestLogger logs the request details
 func requestLogger(logger *slog.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -63,12 +88,31 @@ func requestLogger(logger *slog.Logger) func(next http.Handler) http.Handler {
 	}
 }
 
+// identifyUser stores the caller named by UserIDHeader in the request context
+func identifyUser(logger *slog.Logger) func(next http.Handler) http.Handler {
+	rs := responder{logger: logger}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if header := r.Header.Get(UserIDHeader); header != "" {
+				userID, err := uuid.Parse(header)
+				if err != nil {
+					rs.respondWithError(w, http.StatusBadRequest, "invalid_user_id", "Invalid "+UserIDHeader+" header", nil)
+					return
+				}
+				r = r.WithContext(domain.WithActor(r.Context(), userID))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // corsMiddleware handles Cross-Origin Resource Sharing
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, X-Request-ID, If-Match")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, X-Request-ID, X-User-ID, If-Match")
 		
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusNoContent)
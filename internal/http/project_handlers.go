@@ -0,0 +1,162 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/service"
+)
+
+// projectKey is the context key for the project resolved from the {pid} URL parameter
+type projectKey struct{}
+
+// projectFromContext returns the project a request is scoped to, if any
+func projectFromContext(ctx context.Context) (*domain.Project, bool) {
+	project, ok := ctx.Value(projectKey{}).(*domain.Project)
+	return project, ok
+}
+
+// ProjectHandler handles HTTP requests for projects and mounts the task and
+// status routes below each project
+type ProjectHandler struct {
+	responder
+	service  service.ProjectService
+	tasks    *TaskHandler
+	statuses *StatusHandler
+}
+
+// NewProjectHandler creates a new project handler
+func NewProjectHandler(service service.ProjectService, tasks *TaskHandler, statuses *StatusHandler, logger *slog.Logger) *ProjectHandler {
+	return &ProjectHandler{
+		responder: responder{logger: logger},
+		service:   service,
+		tasks:     tasks,
+		statuses:  statuses,
+	}
+}
+
+// ListProjects handles GET /v1/projects
+func (h *ProjectHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+
+	projects, err := h.service.List(r.Context(), includeArchived)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list projects")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainProjects(projects))
+}
+
+// CreateProject handles POST /v1/projects
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	var payload CreateProjectPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	project, err := h.service.Create(r.Context(), payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to create project")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, fromDomainProject(*project))
+}
+
+// GetProject handles GET /v1/projects/{pid}
+func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	project, _ := projectFromContext(r.Context())
+	h.respondWithJSON(w, http.StatusOK, fromDomainProject(*project))
+}
+
+// PatchProject handles PATCH /v1/projects/{pid}
+func (h *ProjectHandler) PatchProject(w http.ResponseWriter, r *http.Request) {
+	project, _ := projectFromContext(r.Context())
+
+	var payload PatchProjectPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	project, err := h.service.Patch(r.Context(), project.ID, payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to update project")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainProject(*project))
+}
+
+// DeleteProject handles DELETE /v1/projects/{pid}
+func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	project, _ := projectFromContext(r.Context())
+
+	if err := h.service.Delete(r.Context(), project.ID); err != nil {
+		h.respondWithServiceError(w, err, "Failed to delete project")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// resolveProject loads the project named by {pid}, given either as an ID or
+// as a key, and stores it in the request context
+func (h *ProjectHandler) resolveProject(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pid := chi.URLParam(r, "pid")
+
+		var project *domain.Project
+		var err error
+		if id, parseErr := uuid.Parse(pid); parseErr == nil {
+			project, err = h.service.Get(r.Context(), id)
+		} else {
+			project, err = h.service.GetByKey(r.Context(), pid)
+		}
+		if err != nil {
+			h.respondWithServiceError(w, err, "Failed to retrieve project")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), projectKey{}, project)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RegisterRoutes registers all project routes, including the task routes
+// scoped to each project under /projects/{pid}/tasks and the project's
+// statuses under /projects/{pid}/statuses
+func (h *ProjectHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/projects", func(r chi.Router) {
+		r.Get("/", h.ListProjects)
+		r.Post("/", h.CreateProject)
+		r.Route("/{pid}", func(r chi.Router) {
+			r.Use(h.resolveProject)
+			r.Get("/", h.GetProject)
+			r.Patch("/", h.PatchProject)
+			r.Delete("/", h.DeleteProject)
+			h.tasks.RegisterRoutes(r)
+			h.statuses.RegisterProjectRoutes(r)
+		})
+	})
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+
+	"task-svc/internal/config"
+	"task-svc/internal/domain"
+	"task-svc/internal/realtime"
+)
+
+// writeWait bounds the time a WebSocket write may take before the connection
+// is considered dead
+const writeWait = 10 * time.Second
+
+// RealtimeHandler connects WebSocket clients to the realtime hub
+type RealtimeHandler struct {
+	responder
+	hub    *realtime.Hub
+	config config.RealtimeConfig
+}
+
+// NewRealtimeHandler creates a new realtime handler
+func NewRealtimeHandler(hub *realtime.Hub, cfg config.RealtimeConfig, logger *slog.Logger) *RealtimeHandler {
+	cfg.Heartbeat = max(cfg.Heartbeat, time.Second)
+	return &RealtimeHandler{
+		responder: responder{logger: logger},
+		hub:       hub,
+		config:    cfg,
+	}
+}
+
+// Connect handles GET /v1/ws, upgrading the request to a WebSocket that
+// exchanges JSON messages with the hub. The caller named by X-User-ID is the
+// identity shown in presence and typing indicators.
+func (h *RealtimeHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	var userID *uuid.UUID
+	if actor, ok := domain.ActorFromContext(r.Context()); ok {
+		userID = &actor
+	}
+
+	server := websocket.Server{
+		// Like the CORS policy, accept every origin; the caller's identity
+		// comes from the gateway rather than from browser credentials
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			ws.MaxPayloadBytes = h.config.MaxMessageSize
+			h.serve(ws, h.hub.Connect(userID))
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// serve pumps messages between a connection and its hub client until either
+// side ends. Clients are pinged every heartbeat and closed once they have
+// been silent for two.
+func (h *RealtimeHandler) serve(ws *websocket.Conn, client *realtime.Client) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.write(ws, client)
+	}()
+
+	for {
+		if err := ws.SetReadDeadline(time.Now().Add(2 * h.config.Heartbeat)); err != nil {
+			break
+		}
+		var data []byte
+		if err := websocket.Message.Receive(ws, &data); err != nil {
+			break
+		}
+		var msg realtime.Inbound
+		if err := json.Unmarshal(data, &msg); err != nil {
+			h.hub.Reject(client, "invalid_message", "Message is not valid JSON")
+			continue
+		}
+		h.hub.Handle(client, msg)
+	}
+
+	// Closing the connection stops the writer if it is blocked; disconnecting
+	// closes the client's queue if it is waiting for messages
+	ws.Close()
+	h.hub.Disconnect(client)
+	<-done
+}
+
+// write sends the client's queued messages and the heartbeat pings until the
+// queue is closed or a write fails
+func (h *RealtimeHandler) write(ws *websocket.Conn, client *realtime.Client) {
+	heartbeat := time.NewTicker(h.config.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var msg any
+		select {
+		case queued, ok := <-client.Messages():
+			if !ok {
+				ws.Close()
+				return
+			}
+			msg = queued
+		case <-heartbeat.C:
+			msg = realtime.ControlMessage{Type: realtime.TypePing}
+		}
+		if err := h.send(ws, msg); err != nil {
+			ws.Close()
+			return
+		}
+	}
+}
+
+// send writes a message to the connection within writeWait
+func (h *RealtimeHandler) send(ws *websocket.Conn, msg any) error {
+	if err := ws.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+		return err
+	}
+	return websocket.JSON.Send(ws, msg)
+}
+
+// RegisterRoutes registers the realtime routes
+func (h *RealtimeHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/ws", h.Connect)
+}
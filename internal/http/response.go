@@ -0,0 +1,172 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"task-svc/internal/service"
+)
+
+// responder writes JSON and error responses. Handlers embed it so that every
+// resource reports errors in the same shape.
+type responder struct {
+	logger *slog.Logger
+}
+
+// errorResponse represents an error response
+type errorResponse struct {
+	Error struct {
+		Code    string                 `json:"code"`
+		Message string                 `json:"message"`
+		Details map[string]interface{} `json:"details,omitempty"`
+	} `json:"error"`
+}
+
+// respondWithJSON sends a JSON response
+func (rs responder) respondWithJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if data != nil {
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			rs.logger.Error("Error encoding response", "error", err)
+		}
+	}
+}
+
+// respondWithError sends an error response
+func (rs responder) respondWithError(w http.ResponseWriter, status int, code, message string, details map[string]interface{}) {
+	resp := errorResponse{}
+	resp.Error.Code = code
+	resp.Error.Message = message
+	resp.Error.Details = details
+
+	rs.respondWithJSON(w, status, resp)
+}
+
+// respondWithServiceError maps errors returned by the service layer to error responses.
+// Unrecognised errors are logged and reported as internal errors using message.
+func (rs responder) respondWithServiceError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		rs.respondWithError(w, http.StatusNotFound, "not_found", "Task not found", nil)
+	case errors.Is(err, service.ErrVersionNotFound):
+		rs.respondWithError(w, http.StatusNotFound, "not_found", "Task version not found", nil)
+	case errors.Is(err, service.ErrVersionConflict):
+		rs.respondWithError(w, http.StatusConflict, "version_conflict", "Task was modified by another request", nil)
+	case errors.Is(err, service.ErrParentNotFound):
+		rs.respondWithError(w, http.StatusUnprocessableEntity, "invalid_parent", "Parent task not found", nil)
+	case errors.Is(err, service.ErrParentDeleted):
+		rs.respondWithError(w, http.StatusConflict, "parent_deleted", "Restore the parent task first", nil)
+	case errors.Is(err, service.ErrParentDone):
+		rs.respondWithError(w, http.StatusConflict, "parent_done", "Reopen the parent task first", nil)
+	case errors.Is(err, service.ErrParentCycle):
+		rs.respondWithError(w, http.StatusUnprocessableEntity, "parent_cycle", "Parent would make the task its own ancestor", nil)
+	case errors.Is(err, service.ErrOpenSubtasks):
+		rs.respondWithError(w, http.StatusConflict, "open_subtasks", "Task has subtasks that are not closed yet", nil)
+	case errors.Is(err, service.ErrBlocked):
+		var details map[string]interface{}
+		var blocked *service.BlockedError
+		if errors.As(err, &blocked) {
+			ids := make([]string, 0, len(blocked.BlockerIDs))
+			for _, id := range blocked.BlockerIDs {
+				ids = append(ids, id.String())
+			}
+			details = map[string]interface{}{"blocked_by": ids}
+		}
+		rs.respondWithError(w, http.StatusConflict, "blocked", "Task is blocked by unfinished dependencies", details)
+	case errors.Is(err, service.ErrInvalidTransition):
+		var details map[string]interface{}
+		var transition *service.TransitionError
+		if errors.As(err, &transition) {
+			details = map[string]interface{}{
+				"from":    transition.From,
+				"to":      transition.To,
+				"allowed": transition.Allowed,
+			}
+		}
+		rs.respondWithError(w, http.StatusUnprocessableEntity, "invalid_transition", err.Error(), details)
+	case errors.Is(err, service.ErrUnknownStatus):
+		rs.respondWithError(w, http.StatusBadRequest, "invalid_status", "Invalid status value", nil)
+	case errors.Is(err, service.ErrUnknownPriority):
+		rs.respondWithError(w, http.StatusBadRequest, "invalid_priority", "Invalid priority value", nil)
+	case errors.Is(err, service.ErrUnknownSeverity):
+		rs.respondWithError(w, http.StatusBadRequest, "invalid_severity", "Invalid severity value", nil)
+	case errors.Is(err, service.ErrSearchQueryRequired):
+		rs.respondWithError(w, http.StatusBadRequest, "missing_query", "The q parameter is required", nil)
+	case errors.Is(err, service.ErrInvalidRecurrence):
+		rs.respondWithError(w, http.StatusBadRequest, "invalid_recurrence", err.Error(), nil)
+	case errors.Is(err, service.ErrRecurrenceNeedsDueDate):
+		rs.respondWithError(w, http.StatusUnprocessableEntity, "recurrence_needs_due_date", "A recurring task needs a due date", nil)
+	case errors.Is(err, service.ErrNotRecurring):
+		rs.respondWithError(w, http.StatusUnprocessableEntity, "not_recurring", "Task does not recur", nil)
+	case errors.Is(err, service.ErrDependencyCycle):
+		rs.respondWithError(w, http.StatusUnprocessableEntity, "dependency_cycle", "Dependency would create a cycle", nil)
+	case errors.Is(err, service.ErrDependencyExists):
+		rs.respondWithError(w, http.StatusConflict, "dependency_exists", "Dependency already exists", nil)
+	case errors.Is(err, service.ErrDependencyNotFound):
+		rs.respondWithError(w, http.StatusNotFound, "not_found", "Dependency not found", nil)
+	case errors.Is(err, service.ErrStatusNotFound):
+		rs.respondWithError(w, http.StatusNotFound, "not_found", "Status not found", nil)
+	case errors.Is(err, service.ErrStatusExists):
+		rs.respondWithError(w, http.StatusConflict, "status_exists", "A status with this name already exists", nil)
+	case errors.Is(err, service.ErrStatusInUse):
+		rs.respondWithError(w, http.StatusConflict, "status_in_use", "Status is still used by tasks", nil)
+	case errors.Is(err, service.ErrLastTodoStatus):
+		rs.respondWithError(w, http.StatusConflict, "last_todo_status", "At least one status must remain in the todo category", nil)
+	case errors.Is(err, service.ErrLabelNotFound):
+		rs.respondWithError(w, http.StatusNotFound, "not_found", "Label not found", nil)
+	case errors.Is(err, service.ErrLabelExists):
+		rs.respondWithError(w, http.StatusConflict, "label_exists", "A label with this name already exists", nil)
+	case errors.Is(err, service.ErrUnknownLabel):
+		rs.respondWithError(w, http.StatusUnprocessableEntity, "unknown_label", "One or more labels do not exist", nil)
+	case errors.Is(err, service.ErrUserNotFound):
+		rs.respondWithError(w, http.StatusNotFound, "not_found", "User not found", nil)
+	case errors.Is(err, service.ErrUserExists):
+		rs.respondWithError(w, http.StatusConflict, "user_exists", "A user with this email already exists", nil)
+	case errors.Is(err, service.ErrUnknownUser):
+		rs.respondWithError(w, http.StatusUnprocessableEntity, "unknown_user", "Assignee, reporter or acting user does not exist", nil)
+	case errors.Is(err, service.ErrProjectNotFound):
+		rs.respondWithError(w, http.StatusNotFound, "not_found", "Project not found", nil)
+	case errors.Is(err, service.ErrProjectExists):
+		rs.respondWithError(w, http.StatusConflict, "project_exists", "A project with this key already exists", nil)
+	case errors.Is(err, service.ErrProjectArchived):
+		rs.respondWithError(w, http.StatusConflict, "project_archived", "Tasks cannot be added to an archived project", nil)
+	case errors.Is(err, service.ErrProjectNotEmpty):
+		rs.respondWithError(w, http.StatusConflict, "project_not_empty", "Project still has tasks; archive it instead", nil)
+	case errors.Is(err, service.ErrProjectMismatch):
+		rs.respondWithError(w, http.StatusUnprocessableEntity, "project_mismatch", "Parent task belongs to another project", nil)
+	case errors.Is(err, service.ErrUnknownProject):
+		rs.respondWithError(w, http.StatusUnprocessableEntity, "unknown_project", "Project does not exist", nil)
+	case errors.Is(err, service.ErrViewNotFound):
+		rs.respondWithError(w, http.StatusNotFound, "not_found", "View not found", nil)
+	case errors.Is(err, service.ErrViewExists):
+		rs.respondWithError(w, http.StatusConflict, "view_exists", "You already have a view with this name", nil)
+	case errors.Is(err, service.ErrViewForbidden):
+		rs.respondWithError(w, http.StatusForbidden, "forbidden", "Only the owner can change a view", nil)
+	case errors.Is(err, service.ErrActorRequired):
+		rs.respondWithError(w, http.StatusUnauthorized, "unauthenticated", "The "+UserIDHeader+" header is required", nil)
+	case errors.Is(err, service.ErrCommentNotFound):
+		rs.respondWithError(w, http.StatusNotFound, "not_found", "Comment not found", nil)
+	case errors.Is(err, service.ErrCommentParentNotFound):
+		rs.respondWithError(w, http.StatusUnprocessableEntity, "invalid_comment_parent", "Parent comment not found on this task", nil)
+	case errors.Is(err, service.ErrAttachmentNotFound):
+		rs.respondWithError(w, http.StatusNotFound, "not_found", "Attachment not found", nil)
+	case errors.Is(err, service.ErrAttachmentTooLarge):
+		rs.respondWithError(w, http.StatusRequestEntityTooLarge, "attachment_too_large", "Attachment exceeds the size limit", nil)
+	case errors.Is(err, service.ErrAttachmentTypeNotAllowed):
+		rs.respondWithError(w, http.StatusUnsupportedMediaType, "unsupported_media_type", "Attachment content type is not allowed", nil)
+	case errors.Is(err, service.ErrWebhookNotFound):
+		rs.respondWithError(w, http.StatusNotFound, "not_found", "Webhook not found", nil)
+	case errors.Is(err, service.ErrDeliveryNotFound):
+		rs.respondWithError(w, http.StatusNotFound, "not_found", "Webhook delivery not found", nil)
+	case errors.Is(err, service.ErrInvalidWebhookURL):
+		rs.respondWithError(w, http.StatusBadRequest, "invalid_url", "Webhook URL must be an absolute http or https URL", nil)
+	case errors.Is(err, service.ErrUnknownEventType):
+		rs.respondWithError(w, http.StatusBadRequest, "invalid_event_type", err.Error(), nil)
+	default:
+		rs.logger.Error(message, "error", err)
+		rs.respondWithError(w, http.StatusInternalServerError, "internal_error", message, nil)
+	}
+}
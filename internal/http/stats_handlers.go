@@ -0,0 +1,90 @@
+package http
+
+import (
+	"encoding/csv"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"task-svc/internal/domain"
+)
+
+// TaskStats handles GET /v1/tasks/stats. It takes the filters of ListTasks and
+// group_by, a comma-separated list of dimensions, and answers in CSV when
+// format=csv is given or the Accept header leads with text/csv.
+func (h *TaskHandler) TaskStats(w http.ResponseWriter, r *http.Request) {
+	filter, ok := h.parseTaskFilter(w, r, "-created_at")
+	if !ok {
+		return
+	}
+
+	var groupBy []domain.StatsDimension
+	for _, name := range splitList(r.URL.Query().Get("group_by")) {
+		d := domain.StatsDimension(name)
+		if !d.Valid() || slices.Contains(groupBy, d) {
+			names := make([]string, len(domain.StatsDimensions))
+			for i, known := range domain.StatsDimensions {
+				names[i] = string(known)
+			}
+			h.respondWithError(w, http.StatusBadRequest, "invalid_group_by", "group_by takes distinct dimensions out of "+strings.Join(names, ", "), nil)
+			return
+		}
+		groupBy = append(groupBy, d)
+	}
+
+	var asCSV bool
+	switch format := r.URL.Query().Get("format"); format {
+	case "":
+		asCSV = strings.HasPrefix(r.Header.Get("Accept"), "text/csv")
+	case "csv", "json":
+		asCSV = format == "csv"
+	default:
+		h.respondWithError(w, http.StatusBadRequest, "invalid_format", "format must be json or csv", nil)
+		return
+	}
+
+	stats, err := h.service.Stats(r.Context(), filter, groupBy)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to count tasks")
+		return
+	}
+
+	if asCSV {
+		h.respondWithStatsCSV(w, stats)
+		return
+	}
+	h.respondWithJSON(w, http.StatusOK, fromDomainTaskStats(stats))
+}
+
+// respondWithStatsCSV writes task stats as CSV: a header row naming the
+// dimensions and counts, then one row per group with missing keys left empty
+func (h *TaskHandler) respondWithStatsCSV(w http.ResponseWriter, stats *domain.TaskStats) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="task-stats.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	out := csv.NewWriter(w)
+	header := make([]string, 0, len(stats.GroupBy)+3)
+	for _, d := range stats.GroupBy {
+		header = append(header, string(d))
+	}
+	out.Write(append(header, "tasks", "done", "overdue"))
+
+	for _, g := range stats.Groups {
+		row := make([]string, 0, len(g.Keys)+3)
+		for _, key := range g.Keys {
+			if key == nil {
+				row = append(row, "")
+			} else {
+				row = append(row, *key)
+			}
+		}
+		out.Write(append(row, strconv.Itoa(g.Tasks), strconv.Itoa(g.Done), strconv.Itoa(g.Overdue)))
+	}
+
+	out.Flush()
+	if err := out.Error(); err != nil {
+		h.logger.Error("Error writing CSV response", "error", err)
+	}
+}
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"task-svc/internal/service"
+)
+
+// StatusHandler handles HTTP requests for the configurable status set
+type StatusHandler struct {
+	responder
+	service service.StatusService
+}
+
+// NewStatusHandler creates a new status handler
+func NewStatusHandler(service service.StatusService, logger *slog.Logger) *StatusHandler {
+	return &StatusHandler{
+		responder: responder{logger: logger},
+		service:   service,
+	}
+}
+
+// ListStatuses handles GET /v1/statuses, listing the default set, and GET
+// /v1/projects/{pid}/statuses, listing the statuses available to the project
+func (h *StatusHandler) ListStatuses(w http.ResponseWriter, r *http.Request) {
+	var projectID *uuid.UUID
+	if project, ok := projectFromContext(r.Context()); ok {
+		projectID = &project.ID
+	}
+
+	statuses, err := h.service.List(r.Context(), projectID)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list statuses")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainStatusSet(statuses))
+}
+
+// CreateStatus handles POST /v1/statuses and POST /v1/projects/{pid}/statuses
+func (h *StatusHandler) CreateStatus(w http.ResponseWriter, r *http.Request) {
+	var payload CreateStatusPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	// Statuses created below /projects/{pid} always belong to that project
+	if project, ok := projectFromContext(r.Context()); ok {
+		payload.ProjectID = &project.ID
+	}
+
+	status, err := h.service.Create(r.Context(), payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to create status")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, fromDomainStatus(*status))
+}
+
+// GetStatus handles GET /v1/statuses/{id}
+func (h *StatusHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid status ID", nil)
+		return
+	}
+
+	status, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to retrieve status")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainStatus(*status))
+}
+
+// PatchStatus handles PATCH /v1/statuses/{id}
+func (h *StatusHandler) PatchStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid status ID", nil)
+		return
+	}
+
+	var payload PatchStatusPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	status, err := h.service.Patch(r.Context(), id, payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to update status")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainStatus(*status))
+}
+
+// DeleteStatus handles DELETE /v1/statuses/{id}
+func (h *StatusHandler) DeleteStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid status ID", nil)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		h.respondWithServiceError(w, err, "Failed to delete status")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterRoutes registers all status routes
+func (h *StatusHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/statuses", func(r chi.Router) {
+		r.Get("/", h.ListStatuses)
+		r.Post("/", h.CreateStatus)
+		r.Get("/{id}", h.GetStatus)
+		r.Patch("/{id}", h.PatchStatus)
+		r.Delete("/{id}", h.DeleteStatus)
+	})
+}
+
+// RegisterProjectRoutes registers the status routes mounted below
+// /projects/{pid}. A project's statuses are read and changed by ID through
+// the top-level routes.
+func (h *StatusHandler) RegisterProjectRoutes(r chi.Router) {
+	r.Route("/statuses", func(r chi.Router) {
+		r.Get("/", h.ListStatuses)
+		r.Post("/", h.CreateStatus)
+	})
+}
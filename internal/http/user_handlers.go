@@ -0,0 +1,152 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/service"
+)
+
+// UserHandler handles HTTP requests for the users directory
+type UserHandler struct {
+	responder
+	service service.UserService
+}
+
+// NewUserHandler creates a new user handler
+func NewUserHandler(service service.UserService, logger *slog.Logger) *UserHandler {
+	return &UserHandler{
+		responder: responder{logger: logger},
+		service:   service,
+	}
+}
+
+// ListUsers handles GET /v1/users
+func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := h.service.List(r.Context())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list users")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainUsers(users))
+}
+
+// CreateUser handles POST /v1/users
+func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var payload CreateUserPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	user, err := h.service.Create(r.Context(), payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to create user")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, fromDomainUser(*user))
+}
+
+// GetUser handles GET /v1/users/{id}
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid user ID", nil)
+		return
+	}
+
+	user, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to retrieve user")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainUser(*user))
+}
+
+// GetCurrentUser handles GET /v1/users/me
+func (h *UserHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := domain.ActorFromContext(r.Context())
+	if !ok {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthenticated", "The "+UserIDHeader+" header is required", nil)
+		return
+	}
+
+	user, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to retrieve user")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainUser(*user))
+}
+
+// PatchUser handles PATCH /v1/users/{id}
+func (h *UserHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid user ID", nil)
+		return
+	}
+
+	var payload PatchUserPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	user, err := h.service.Patch(r.Context(), id, payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to update user")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainUser(*user))
+}
+
+// DeleteUser handles DELETE /v1/users/{id}
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid user ID", nil)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		h.respondWithServiceError(w, err, "Failed to delete user")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterRoutes registers all user routes
+func (h *UserHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/users", func(r chi.Router) {
+		r.Get("/", h.ListUsers)
+		r.Post("/", h.CreateUser)
+		r.Get("/me", h.GetCurrentUser)
+		r.Get("/{id}", h.GetUser)
+		r.Patch("/{id}", h.PatchUser)
+		r.Delete("/{id}", h.DeleteUser)
+	})
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -10,6 +11,19 @@ import (
 // Validate is a singleton validator instance
 var Validate = validator.New()
 
+// projectKeyPattern matches project keys such as BILL or OPS2
+var projectKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]{1,9}$`)
+
+// taskColumns holds the field names of TaskResponse, which views may show as columns
+var taskColumns = func() map[string]bool {
+	columns := map[string]bool{}
+	t := reflect.TypeOf(TaskResponse{})
+	for i := 0; i < t.NumField(); i++ {
+		columns[strings.SplitN(t.Field(i).Tag.Get("json"), ",", 2)[0]] = true
+	}
+	return columns
+}()
+
 // init initializes the validator with custom settings
 func init() {
 	// Register validation for ensuring status is a valid enum value
@@ -20,6 +34,14 @@ func init() {
 		}
 		return name
 	})
+
+	Validate.RegisterValidation("projectkey", func(fl validator.FieldLevel) bool {
+		return projectKeyPattern.MatchString(fl.Field().String())
+	})
+
+	Validate.RegisterValidation("taskcolumn", func(fl validator.FieldLevel) bool {
+		return taskColumns[fl.Field().String()]
+	})
 }
 
 // parseValidationErrors converts validator errors into a map for error responses
@@ -41,6 +63,10 @@ func parseValidationErrors(err error) map[string]interface{} {
 				message = "Value exceeds maximum allowed value"
 			case "oneof":
 				message = "Value must be one of the allowed values: " + e.Param()
+			case "projectkey":
+				message = "Must be 2-10 letters or digits, starting with a letter"
+			case "taskcolumn":
+				message = "Must be a task field name"
 			default:
 				message = "Invalid value"
 			}
@@ -0,0 +1,230 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"task-svc/internal/service"
+)
+
+// viewFilterParams lists the task list query parameters a view's filter may set
+var viewFilterParams = map[string]bool{
+	"filter":           true,
+	"q":                true,
+	"status":           true,
+	"priority":         true,
+	"severity":         true,
+	"parent_id":        true,
+	"assignee":         true,
+	"labels_any":       true,
+	"labels_all":       true,
+	"labels_none":      true,
+	"include_deleted":  true,
+	"include_archived": true,
+	"archived":         true,
+}
+
+// viewPageParams lists the query parameters that page through a view's tasks
+var viewPageParams = []string{"page", "page_size", "after", "before", "include_total"}
+
+// ViewHandler handles HTTP requests for saved views and lists their tasks
+// through the task handler
+type ViewHandler struct {
+	responder
+	service service.ViewService
+	tasks   *TaskHandler
+}
+
+// NewViewHandler creates a new view handler
+func NewViewHandler(service service.ViewService, tasks *TaskHandler, logger *slog.Logger) *ViewHandler {
+	return &ViewHandler{
+		responder: responder{logger: logger},
+		service:   service,
+		tasks:     tasks,
+	}
+}
+
+// ListViews handles GET /v1/views
+func (h *ViewHandler) ListViews(w http.ResponseWriter, r *http.Request) {
+	views, err := h.service.List(r.Context())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list views")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainViews(views))
+}
+
+// CreateView handles POST /v1/views
+func (h *ViewHandler) CreateView(w http.ResponseWriter, r *http.Request) {
+	var payload CreateViewPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+	if !h.checkViewQuery(w, r, payload.Filter, payload.Sort) {
+		return
+	}
+
+	view, err := h.service.Create(r.Context(), payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to create view")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, fromDomainView(*view))
+}
+
+// GetView handles GET /v1/views/{id}
+func (h *ViewHandler) GetView(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid view ID", nil)
+		return
+	}
+
+	view, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to retrieve view")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainView(*view))
+}
+
+// PatchView handles PATCH /v1/views/{id}
+func (h *ViewHandler) PatchView(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid view ID", nil)
+		return
+	}
+
+	var payload PatchViewPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+	var sort string
+	if payload.Sort != nil {
+		sort = *payload.Sort
+	}
+	if !h.checkViewQuery(w, r, payload.Filter, sort) {
+		return
+	}
+
+	view, err := h.service.Patch(r.Context(), id, payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to update view")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainView(*view))
+}
+
+// DeleteView handles DELETE /v1/views/{id}
+func (h *ViewHandler) DeleteView(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid view ID", nil)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		h.respondWithServiceError(w, err, "Failed to delete view")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListViewTasks handles GET /v1/views/{id}/tasks. The view's filter and sort
+// replace the query, apart from the paging parameters, and the request is
+// then listed exactly as GET /v1/tasks would list it; assignee=me therefore
+// names the caller, not the owner of the view.
+func (h *ViewHandler) ListViewTasks(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid view ID", nil)
+		return
+	}
+
+	view, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to retrieve view")
+		return
+	}
+
+	query := viewQuery(view.Filter, view.Sort)
+	for _, param := range viewPageParams {
+		if value := r.URL.Query().Get(param); value != "" {
+			query.Set(param, value)
+		}
+	}
+
+	list := r.Clone(r.Context())
+	list.URL.RawQuery = query.Encode()
+	h.tasks.ListTasks(w, list)
+}
+
+// checkViewQuery checks a view's filter and sort the way a task list request
+// carrying them would be checked. It writes an error response and returns
+// false when either is invalid.
+func (h *ViewHandler) checkViewQuery(w http.ResponseWriter, r *http.Request, filter map[string]string, sort string) bool {
+	unknown := map[string]interface{}{}
+	for param := range filter {
+		if !viewFilterParams[param] {
+			unknown["filter."+param] = "Not a task filter parameter"
+		}
+	}
+	if len(unknown) > 0 {
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", unknown)
+		return false
+	}
+
+	check := r.Clone(r.Context())
+	check.URL.RawQuery = viewQuery(filter, sort).Encode()
+	_, ok := h.tasks.parseTaskFilter(w, check, "-created_at")
+	return ok
+}
+
+// viewQuery builds the task list query of a view's filter and sort
+func viewQuery(filter map[string]string, sort string) url.Values {
+	query := url.Values{}
+	for param, value := range filter {
+		query.Set(param, value)
+	}
+	if sort != "" {
+		query.Set("sort", sort)
+	}
+	return query
+}
+
+// RegisterRoutes registers all view routes
+func (h *ViewHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/views", func(r chi.Router) {
+		r.Get("/", h.ListViews)
+		r.Post("/", h.CreateView)
+		r.Get("/{id}", h.GetView)
+		r.Patch("/{id}", h.PatchView)
+		r.Delete("/{id}", h.DeleteView)
+		r.Get("/{id}/tasks", h.ListViewTasks)
+	})
+}
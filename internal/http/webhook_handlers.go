@@ -0,0 +1,203 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"task-svc/internal/config"
+	"task-svc/internal/domain"
+	"task-svc/internal/service"
+	"task-svc/pkg/pagination"
+)
+
+// WebhookHandler handles HTTP requests for webhook subscriptions and their deliveries
+type WebhookHandler struct {
+	responder
+	service          service.WebhookService
+	paginationConfig config.PaginationConfig
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(service service.WebhookService, paginationConfig config.PaginationConfig, logger *slog.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		responder:        responder{logger: logger},
+		service:          service,
+		paginationConfig: paginationConfig,
+	}
+}
+
+// ListWebhooks handles GET /v1/webhooks
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.service.List(r.Context())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list webhooks")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainWebhooks(webhooks))
+}
+
+// CreateWebhook handles POST /v1/webhooks. The response is the only one that
+// includes the signing secret.
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var payload CreateWebhookPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	webhook, err := h.service.Create(r.Context(), payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to create webhook")
+		return
+	}
+
+	resp := fromDomainWebhook(*webhook)
+	resp.Secret = webhook.Secret
+	h.respondWithJSON(w, http.StatusCreated, resp)
+}
+
+// GetWebhook handles GET /v1/webhooks/{id}
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseWebhookID(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to retrieve webhook")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainWebhook(*webhook))
+}
+
+// PatchWebhook handles PATCH /v1/webhooks/{id}
+func (h *WebhookHandler) PatchWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseWebhookID(w, r)
+	if !ok {
+		return
+	}
+
+	var payload PatchWebhookPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "bad_request", "Invalid request body", nil)
+		return
+	}
+	// Validate the request
+	if err := Validate.Struct(payload); err != nil {
+		validationErrors := parseValidationErrors(err)
+		h.respondWithError(w, http.StatusBadRequest, "validation_error", "Validation failed", validationErrors)
+		return
+	}
+
+	webhook, err := h.service.Patch(r.Context(), id, payload.ToDomain())
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to update webhook")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainWebhook(*webhook))
+}
+
+// DeleteWebhook handles DELETE /v1/webhooks/{id}
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseWebhookID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		h.respondWithServiceError(w, err, "Failed to delete webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /v1/webhooks/{id}/deliveries
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseWebhookID(w, r)
+	if !ok {
+		return
+	}
+
+	_, limit := pagination.Parse("", r.URL.Query().Get("limit"), pagination.DefaultParams{
+		DefaultPage: 1,
+		DefaultSize: h.paginationConfig.DefaultSize,
+		MaxSize:     h.paginationConfig.MaxSize,
+	})
+	filter := domain.DeliveryFilter{Limit: limit}
+
+	if statusParam := r.URL.Query().Get("status"); statusParam != "" {
+		status := domain.DeliveryStatus(statusParam)
+		if !status.Valid() {
+			h.respondWithError(w, http.StatusBadRequest, "invalid_status", "Status must be pending, delivered or dead", nil)
+			return
+		}
+		filter.Status = &status
+	}
+
+	deliveries, err := h.service.Deliveries(r.Context(), id, filter)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to list webhook deliveries")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, fromDomainDeliveries(deliveries))
+}
+
+// Redeliver handles POST /v1/webhooks/{id}/deliveries/{deliveryId}/redeliver
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseWebhookID(w, r)
+	if !ok {
+		return
+	}
+	deliveryID, err := uuid.Parse(chi.URLParam(r, "deliveryId"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid delivery ID", nil)
+		return
+	}
+
+	delivery, err := h.service.Redeliver(r.Context(), id, deliveryID)
+	if err != nil {
+		h.respondWithServiceError(w, err, "Failed to redeliver webhook delivery")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusAccepted, fromDomainDelivery(*delivery))
+}
+
+// parseWebhookID reads the webhook ID from the URL
+func (h *WebhookHandler) parseWebhookID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid_id", "Invalid webhook ID", nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// RegisterRoutes registers all webhook routes
+func (h *WebhookHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Get("/", h.ListWebhooks)
+		r.Post("/", h.CreateWebhook)
+		r.Get("/{id}", h.GetWebhook)
+		r.Patch("/{id}", h.PatchWebhook)
+		r.Delete("/{id}", h.DeleteWebhook)
+		r.Get("/{id}/deliveries", h.ListDeliveries)
+		r.Post("/{id}/deliveries/{deliveryId}/redeliver", h.Redeliver)
+	})
+}
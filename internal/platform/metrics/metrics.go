@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"bufio"
+	"net"
 	"net/http"
 	"time"
 
@@ -42,6 +44,49 @@ var (
 		},
 		[]string{"query"},
 	)
+
+	// JobRunsTotal counts background job runs by outcome
+	JobRunsTotal = promauto.With(Registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "job_runs_total",
+			Help: "Total number of background job runs",
+		},
+		[]string{"job", "result"},
+	)
+
+	// RemindersFiredTotal counts the due date reminders fired
+	RemindersFiredTotal = promauto.With(Registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "reminders_fired_total",
+			Help: "Total number of task due date reminders fired",
+		},
+		[]string{"threshold"},
+	)
+
+	// OutboxEventsRelayedTotal counts the outbox events relayed to every sink
+	OutboxEventsRelayedTotal = promauto.With(Registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "outbox_events_relayed_total",
+			Help: "Total number of task events relayed from the outbox",
+		},
+		[]string{"type"},
+	)
+
+	// EventStreamSubscribers tracks the clients following the live event stream
+	EventStreamSubscribers = promauto.With(Registry).NewGauge(
+		prometheus.GaugeOpts{
+			Name: "event_stream_subscribers",
+			Help: "Number of clients subscribed to the live task event stream",
+		},
+	)
+
+	// WebSocketConnections tracks the open WebSocket connections
+	WebSocketConnections = promauto.With(Registry).NewGauge(
+		prometheus.GaugeOpts{
+			Name: "websocket_connections",
+			Help: "Number of open WebSocket connections",
+		},
+	)
 )
 
 // Handler returns an HTTP handler for exposing metrics
@@ -93,6 +138,21 @@ func (w *responseWriter) Write(b []byte) (int, error) {
 	return w.ResponseWriter.Write(b)
 }
 
+// Hijack lets WebSocket handlers take over the connection
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the wrapped writer, so that http.ResponseController can
+// reach its flushing and deadline methods
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 // TrackDBQuery records the duration of a database query
 func TrackDBQuery(queryName string) func() {
 	start := time.Now()
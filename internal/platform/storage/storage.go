@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"task-svc/internal/config"
+)
+
+// ErrBlobNotFound is returned when no blob is stored under a key
+var ErrBlobNotFound = errors.New("blob not found")
+
+// Blob describes the content handed to BlobStore.Put
+type Blob struct {
+	Size        int64
+	ContentType string
+	// SHA256 is the hex-encoded checksum of the content. Stores that can
+	// verify it on write (such as S3) do so.
+	SHA256 string
+}
+
+// BlobStore keeps opaque blobs under slash-separated keys
+type BlobStore interface {
+	// Put stores the content read from r under key, replacing any previous blob
+	Put(ctx context.Context, key string, r io.Reader, blob Blob) error
+	// Get opens the blob stored under key. The caller closes the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key. Deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// New creates the blob store selected by cfg.Driver
+func New(cfg config.StorageConfig) (BlobStore, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case "", "local":
+		return NewLocalStore(cfg.LocalDir)
+	case "s3":
+		return NewS3Store(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
+
+// validKey reports whether key is a relative, slash-separated path without
+// empty, "." or ".." segments
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return false
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." || strings.Contains(segment, "\\") {
+			return false
+		}
+	}
+	return true
+}
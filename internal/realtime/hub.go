@@ -0,0 +1,379 @@
+// Package realtime fans task events, presence and typing indicators out to
+// connected clients. The hub knows nothing about the transport: a client is a
+// queue of outgoing messages, fed by Handle and Publish, which the transport
+// drains.
+package realtime
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"task-svc/internal/config"
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/metrics"
+	"task-svc/internal/service"
+)
+
+// resubscribeDelay is how long Follow waits before subscribing to the event
+// stream again after its subscription ended
+const resubscribeDelay = time.Second
+
+// Client is a connection to the hub
+type Client struct {
+	userID *uuid.UUID
+	send   chan any
+
+	// Guarded by the hub's mutex
+	subscriptions map[string]subscription
+	viewing       map[uuid.UUID]struct{}
+}
+
+// Messages returns the queue of messages to send to the client. It is closed
+// once the client has been disconnected, including when it fell behind.
+func (c *Client) Messages() <-chan any {
+	return c.send
+}
+
+// subscription selects the events a client receives, either by task ID or by filter
+type subscription struct {
+	taskIDs map[uuid.UUID]struct{}
+	filter  *domain.EventFilter
+}
+
+// matches reports whether the subscription selects event
+func (s subscription) matches(event domain.TaskEvent) bool {
+	if s.filter != nil {
+		return s.filter.Matches(event)
+	}
+	_, ok := s.taskIDs[event.Task.ID]
+	return ok
+}
+
+// Hub tracks the connected clients with their subscriptions and the tasks
+// they are viewing. Every method is safe for concurrent use.
+type Hub struct {
+	logger           *slog.Logger
+	queueSize        int
+	maxSubscriptions int
+
+	mu      sync.Mutex
+	clients map[*Client]struct{}
+	viewers map[uuid.UUID]map[*Client]struct{}
+}
+
+// NewHub creates a new hub
+func NewHub(cfg config.RealtimeConfig, logger *slog.Logger) *Hub {
+	return &Hub{
+		logger:           logger,
+		queueSize:        max(cfg.QueueSize, 1),
+		maxSubscriptions: max(cfg.MaxSubscriptions, 1),
+		clients:          make(map[*Client]struct{}),
+		viewers:          make(map[uuid.UUID]map[*Client]struct{}),
+	}
+}
+
+// Connect registers a client acting as userID; anonymous clients may
+// subscribe to events but cannot view tasks or type
+func (h *Hub) Connect(userID *uuid.UUID) *Client {
+	c := &Client{
+		userID:        userID,
+		send:          make(chan any, h.queueSize),
+		subscriptions: make(map[string]subscription),
+		viewing:       make(map[uuid.UUID]struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clients[c] = struct{}{}
+	metrics.WebSocketConnections.Inc()
+
+	return c
+}
+
+// Disconnect removes a client, ending its presence on the tasks it was
+// viewing. Disconnecting a client twice is harmless.
+func (h *Hub) Disconnect(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.drop(c)
+}
+
+// Close disconnects every client
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.clients {
+		h.drop(c)
+	}
+}
+
+// Handle processes a message received from a client
+func (h *Hub) Handle(c *Client, msg Inbound) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[c]; !ok {
+		return
+	}
+
+	switch msg.Type {
+	case TypeSubscribe:
+		h.subscribe(c, msg)
+	case TypeUnsubscribe:
+		if msg.ID == "" {
+			h.reject(c, msg.ID, "invalid_message", "Subscription ID is required")
+			return
+		}
+		delete(c.subscriptions, msg.ID)
+		h.enqueue(c, AckMessage{Type: TypeAck, ID: msg.ID})
+	case TypeView, TypeLeave, TypeTyping:
+		if c.userID == nil {
+			h.reject(c, msg.ID, "identity_required", "Presence and typing require an identified user")
+			return
+		}
+		if msg.TaskID == nil {
+			h.reject(c, msg.ID, "invalid_message", "Task ID is required")
+			return
+		}
+		switch msg.Type {
+		case TypeView:
+			h.view(c, *msg.TaskID)
+		case TypeLeave:
+			h.leave(c, *msg.TaskID)
+		default:
+			typing := msg.Typing == nil || *msg.Typing
+			h.notifyAudience(*msg.TaskID, c, false, TypingMessage{Type: TypeTyping, TaskID: *msg.TaskID, UserID: *c.userID, Typing: typing})
+		}
+	case TypePing:
+		h.enqueue(c, ControlMessage{Type: TypePong})
+	case TypePong:
+		// Only proves the client is alive
+	default:
+		h.reject(c, msg.ID, "unknown_message_type", "Unknown message type "+msg.Type)
+	}
+}
+
+// Reject queues an error message for a client, for messages the transport
+// could not decode
+func (h *Hub) Reject(c *Client, code, message string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[c]; ok {
+		h.reject(c, "", code, message)
+	}
+}
+
+// Publish queues a task event for every client with a matching subscription
+func (h *Hub) Publish(event domain.TaskEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for c := range h.clients {
+		var matched []string
+		for id, sub := range c.subscriptions {
+			if sub.matches(event) {
+				matched = append(matched, id)
+			}
+		}
+		if len(matched) > 0 {
+			slices.Sort(matched)
+			h.enqueue(c, EventMessage{Type: TypeEvent, Subscriptions: matched, Event: event})
+		}
+	}
+}
+
+// Reset tells every client that events may have been missed
+func (h *Hub) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.clients {
+		h.enqueue(c, ControlMessage{Type: TypeReset})
+	}
+}
+
+// Follow publishes the events of stream until ctx is done. When the
+// subscription ends, it subscribes again from the last event received and
+// resets the clients if events were missed in between.
+func (h *Hub) Follow(ctx context.Context, stream service.EventStream) {
+	var lastSeq int64
+	for ctx.Err() == nil {
+		sub, resumed, err := stream.Subscribe(ctx, domain.EventFilter{}, lastSeq)
+		if err != nil {
+			h.logger.Error("Failed to subscribe to task events", "error", err)
+		} else {
+			if !resumed {
+				h.Reset()
+			}
+			lastSeq = h.drain(ctx, sub, lastSeq)
+			sub.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+// drain publishes the events of a subscription until it ends or ctx is
+// done, and returns the seq of the last one
+func (h *Hub) drain(ctx context.Context, sub *service.Subscription, lastSeq int64) int64 {
+	for {
+		select {
+		case <-ctx.Done():
+			return lastSeq
+		case event, ok := <-sub.Events:
+			if !ok {
+				return lastSeq
+			}
+			lastSeq = event.Seq
+			h.Publish(event)
+		}
+	}
+}
+
+// subscribe adds or replaces a subscription of c. h.mu must be held.
+func (h *Hub) subscribe(c *Client, msg Inbound) {
+	if msg.ID == "" {
+		h.reject(c, msg.ID, "invalid_message", "Subscription ID is required")
+		return
+	}
+	if (len(msg.TaskIDs) > 0) == (msg.Filter != nil) {
+		h.reject(c, msg.ID, "invalid_message", "Subscribe to either task IDs or a filter")
+		return
+	}
+	if _, ok := c.subscriptions[msg.ID]; !ok && len(c.subscriptions) >= h.maxSubscriptions {
+		h.reject(c, msg.ID, "too_many_subscriptions", "Subscription limit reached")
+		return
+	}
+
+	var sub subscription
+	if msg.Filter != nil {
+		sub.filter = &domain.EventFilter{Statuses: msg.Filter.Status, ProjectID: msg.Filter.ProjectID}
+	} else {
+		sub.taskIDs = make(map[uuid.UUID]struct{}, len(msg.TaskIDs))
+		for _, id := range msg.TaskIDs {
+			sub.taskIDs[id] = struct{}{}
+		}
+	}
+	c.subscriptions[msg.ID] = sub
+	h.enqueue(c, AckMessage{Type: TypeAck, ID: msg.ID})
+}
+
+// view marks c as viewing a task. h.mu must be held.
+func (h *Hub) view(c *Client, taskID uuid.UUID) {
+	if _, ok := c.viewing[taskID]; ok {
+		return
+	}
+	c.viewing[taskID] = struct{}{}
+	if h.viewers[taskID] == nil {
+		h.viewers[taskID] = make(map[*Client]struct{})
+	}
+	h.viewers[taskID][c] = struct{}{}
+	h.announcePresence(taskID)
+}
+
+// leave ends c's presence on a task. h.mu must be held.
+func (h *Hub) leave(c *Client, taskID uuid.UUID) {
+	if _, ok := c.viewing[taskID]; !ok {
+		return
+	}
+	delete(c.viewing, taskID)
+	delete(h.viewers[taskID], c)
+	if len(h.viewers[taskID]) == 0 {
+		delete(h.viewers, taskID)
+	}
+	h.announcePresence(taskID)
+}
+
+// announcePresence sends the current viewers of a task to its audience. A
+// user viewing it from several connections is listed once. h.mu must be held.
+func (h *Hub) announcePresence(taskID uuid.UUID) {
+	viewers := []uuid.UUID{}
+	for c := range h.viewers[taskID] {
+		if !slices.Contains(viewers, *c.userID) {
+			viewers = append(viewers, *c.userID)
+		}
+	}
+	slices.SortFunc(viewers, func(a, b uuid.UUID) int {
+		return slices.Compare(a[:], b[:])
+	})
+
+	h.notifyAudience(taskID, nil, true, PresenceMessage{Type: TypePresence, TaskID: taskID, Viewers: viewers})
+}
+
+// notifyAudience queues msg for the clients viewing a task or subscribed to
+// it by ID, except the sender. Typing indicators are best effort and are
+// skipped for clients that are behind, rather than closing them.
+// h.mu must be held.
+func (h *Hub) notifyAudience(taskID uuid.UUID, sender *Client, required bool, msg any) {
+	for c := range h.clients {
+		if c == sender || !h.inAudience(c, taskID) {
+			continue
+		}
+		if required {
+			h.enqueue(c, msg)
+		} else {
+			select {
+			case c.send <- msg:
+			default:
+			}
+		}
+	}
+}
+
+// inAudience reports whether c views a task or is subscribed to it by ID.
+// h.mu must be held.
+func (h *Hub) inAudience(c *Client, taskID uuid.UUID) bool {
+	if _, ok := c.viewing[taskID]; ok {
+		return true
+	}
+	for _, sub := range c.subscriptions {
+		if _, ok := sub.taskIDs[taskID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// reject queues an error message for c. h.mu must be held.
+func (h *Hub) reject(c *Client, id, code, message string) {
+	h.enqueue(c, ErrorMessage{Type: TypeError, ID: id, Code: code, Message: message})
+}
+
+// enqueue queues msg for c, disconnecting c when its queue is full: a client
+// that cannot keep up would otherwise miss messages silently or hold up
+// everyone else. h.mu must be held.
+func (h *Hub) enqueue(c *Client, msg any) {
+	select {
+	case c.send <- msg:
+	default:
+		h.logger.Warn("Disconnecting slow WebSocket client")
+		h.drop(c)
+	}
+}
+
+// drop removes c and closes its queue, then announces the presence it ended.
+// h.mu must be held.
+func (h *Hub) drop(c *Client) {
+	if _, ok := h.clients[c]; !ok {
+		return
+	}
+	delete(h.clients, c)
+	close(c.send)
+	metrics.WebSocketConnections.Dec()
+
+	for taskID := range c.viewing {
+		delete(h.viewers[taskID], c)
+		if len(h.viewers[taskID]) == 0 {
+			delete(h.viewers, taskID)
+		}
+	}
+	for taskID := range c.viewing {
+		h.announcePresence(taskID)
+	}
+}
@@ -0,0 +1,241 @@
+package realtime
+
+import (
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"task-svc/internal/config"
+	"task-svc/internal/domain"
+)
+
+func newTestHub(queueSize int) *Hub {
+	return NewHub(config.RealtimeConfig{QueueSize: queueSize, MaxSubscriptions: 10}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// received takes the messages queued for c without blocking and reports
+// whether its queue has been closed
+func received(c *Client) (msgs []any, closed bool) {
+	for {
+		select {
+		case msg, ok := <-c.Messages():
+			if !ok {
+				return msgs, true
+			}
+			msgs = append(msgs, msg)
+		default:
+			return msgs, false
+		}
+	}
+}
+
+func user() *uuid.UUID {
+	id := uuid.New()
+	return &id
+}
+
+func TestSlowClientDisconnected(t *testing.T) {
+	taskID := uuid.New()
+	event := domain.TaskEvent{ID: uuid.New(), Type: domain.EventTaskUpdated, Task: domain.Task{ID: taskID}}
+
+	tests := []struct {
+		name      string
+		queueSize int
+		events    int
+		wantDrop  bool
+	}{
+		{name: "queue with room", queueSize: 4, events: 2, wantDrop: false},
+		{name: "queue filled exactly", queueSize: 4, events: 3, wantDrop: false},
+		{name: "queue overflowing", queueSize: 2, events: 2, wantDrop: true},
+		{name: "single slot", queueSize: 1, events: 1, wantDrop: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := newTestHub(tt.queueSize)
+			defer hub.Close()
+
+			// The subscription's ack takes the first slot of the queue
+			c := hub.Connect(nil)
+			hub.Handle(c, Inbound{Type: TypeSubscribe, ID: "s", TaskIDs: []uuid.UUID{taskID}})
+			for i := 0; i < tt.events; i++ {
+				hub.Publish(event)
+			}
+
+			msgs, closed := received(c)
+			if closed != tt.wantDrop {
+				t.Fatalf("closed = %v, want %v", closed, tt.wantDrop)
+			}
+			if len(msgs) > tt.queueSize {
+				t.Fatalf("received %d messages, more than the queue size %d", len(msgs), tt.queueSize)
+			}
+
+			// A dropped client receives nothing more
+			hub.Publish(event)
+			if more, _ := received(c); tt.wantDrop && len(more) > 0 {
+				t.Fatalf("received %v after being disconnected", more)
+			}
+		})
+	}
+}
+
+func TestSlowViewerPresenceEnds(t *testing.T) {
+	hub := newTestHub(2)
+	defer hub.Close()
+	taskID := uuid.New()
+
+	slow, other := hub.Connect(user()), hub.Connect(user())
+	hub.Handle(slow, Inbound{Type: TypeView, TaskID: &taskID})
+	hub.Handle(other, Inbound{Type: TypeView, TaskID: &taskID})
+	received(other)
+
+	// The slow client never reads: the second presence filled its queue, and
+	// the ping overflows it
+	hub.Handle(slow, Inbound{Type: TypePing})
+	if _, closed := received(slow); !closed {
+		t.Fatal("slow client was not disconnected")
+	}
+
+	msgs, _ := received(other)
+	if len(msgs) != 1 {
+		t.Fatalf("other client received %v, want one presence", msgs)
+	}
+	presence, ok := msgs[0].(PresenceMessage)
+	if !ok || !slices.Equal(presence.Viewers, []uuid.UUID{*other.userID}) {
+		t.Fatalf("presence = %#v, want only the other client", msgs[0])
+	}
+}
+
+func TestPresenceListsUsersOnce(t *testing.T) {
+	alice, bob := user(), user()
+
+	tests := []struct {
+		name        string
+		connections []*uuid.UUID
+		leaving     int
+		want        []uuid.UUID
+	}{
+		{name: "one connection", connections: []*uuid.UUID{alice}, leaving: -1, want: []uuid.UUID{*alice}},
+		{name: "same user twice", connections: []*uuid.UUID{alice, alice}, leaving: -1, want: []uuid.UUID{*alice}},
+		{name: "two users", connections: []*uuid.UUID{alice, bob, alice}, leaving: -1, want: []uuid.UUID{*alice, *bob}},
+		{name: "user still viewing from another connection", connections: []*uuid.UUID{alice, alice}, leaving: 0, want: []uuid.UUID{*alice}},
+		{name: "last connection of a user leaving", connections: []*uuid.UUID{alice, bob}, leaving: 1, want: []uuid.UUID{*alice}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := newTestHub(16)
+			defer hub.Close()
+			taskID := uuid.New()
+			observer := hub.Connect(nil)
+			hub.Handle(observer, Inbound{Type: TypeSubscribe, ID: "s", TaskIDs: []uuid.UUID{taskID}})
+
+			clients := make([]*Client, len(tt.connections))
+			for i, id := range tt.connections {
+				clients[i] = hub.Connect(id)
+				hub.Handle(clients[i], Inbound{Type: TypeView, TaskID: &taskID})
+			}
+			if tt.leaving >= 0 {
+				hub.Handle(clients[tt.leaving], Inbound{Type: TypeLeave, TaskID: &taskID})
+			}
+
+			msgs, _ := received(observer)
+			presence, ok := msgs[len(msgs)-1].(PresenceMessage)
+			if !ok {
+				t.Fatalf("last message = %#v, want a presence", msgs[len(msgs)-1])
+			}
+			want := slices.Clone(tt.want)
+			slices.SortFunc(want, func(a, b uuid.UUID) int { return slices.Compare(a[:], b[:]) })
+			if !slices.Equal(presence.Viewers, want) {
+				t.Errorf("viewers = %v, want %v", presence.Viewers, want)
+			}
+		})
+	}
+}
+
+func TestTypingSkipsSender(t *testing.T) {
+	stopped := false
+
+	tests := []struct {
+		name       string
+		typing     *bool
+		wantTyping bool
+	}{
+		{name: "typing by default", typing: nil, wantTyping: true},
+		{name: "stopped typing", typing: &stopped, wantTyping: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := newTestHub(16)
+			defer hub.Close()
+			taskID := uuid.New()
+
+			sender, viewer, subscriber, outsider := hub.Connect(user()), hub.Connect(user()), hub.Connect(nil), hub.Connect(user())
+			hub.Handle(sender, Inbound{Type: TypeView, TaskID: &taskID})
+			hub.Handle(viewer, Inbound{Type: TypeView, TaskID: &taskID})
+			hub.Handle(subscriber, Inbound{Type: TypeSubscribe, ID: "s", TaskIDs: []uuid.UUID{taskID}})
+			for _, c := range []*Client{sender, viewer, subscriber, outsider} {
+				received(c)
+			}
+
+			hub.Handle(sender, Inbound{Type: TypeTyping, TaskID: &taskID, Typing: tt.typing})
+
+			want := TypingMessage{Type: TypeTyping, TaskID: taskID, UserID: *sender.userID, Typing: tt.wantTyping}
+			for name, c := range map[string]*Client{"viewer": viewer, "subscriber": subscriber} {
+				msgs, _ := received(c)
+				if len(msgs) != 1 || msgs[0] != want {
+					t.Errorf("%s received %v, want %v", name, msgs, want)
+				}
+			}
+			for name, c := range map[string]*Client{"sender": sender, "outsider": outsider} {
+				if msgs, _ := received(c); len(msgs) > 0 {
+					t.Errorf("%s received %v, want nothing", name, msgs)
+				}
+			}
+		})
+	}
+}
+
+func TestTypingSkipsFullQueues(t *testing.T) {
+	hub := newTestHub(2)
+	defer hub.Close()
+	taskID := uuid.New()
+
+	sender, viewer := hub.Connect(user()), hub.Connect(user())
+	hub.Handle(viewer, Inbound{Type: TypeView, TaskID: &taskID})
+	hub.Handle(sender, Inbound{Type: TypeView, TaskID: &taskID})
+
+	// The viewer's queue holds two presences; typing is dropped instead of
+	// disconnecting it
+	hub.Handle(sender, Inbound{Type: TypeTyping, TaskID: &taskID})
+	msgs, closed := received(viewer)
+	if closed {
+		t.Fatal("viewer was disconnected by a typing indicator")
+	}
+	for _, msg := range msgs {
+		if _, ok := msg.(TypingMessage); ok {
+			t.Fatalf("viewer with a full queue received %v", msg)
+		}
+	}
+}
+
+func TestTypingRequiresIdentity(t *testing.T) {
+	hub := newTestHub(4)
+	defer hub.Close()
+	taskID := uuid.New()
+
+	c := hub.Connect(nil)
+	hub.Handle(c, Inbound{Type: TypeTyping, ID: "t", TaskID: &taskID})
+
+	msgs, _ := received(c)
+	if len(msgs) != 1 {
+		t.Fatalf("received %v, want one error", msgs)
+	}
+	if msg, ok := msgs[0].(ErrorMessage); !ok || msg.Code != "identity_required" {
+		t.Errorf("received %#v, want an identity_required error", msgs[0])
+	}
+}
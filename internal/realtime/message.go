@@ -0,0 +1,94 @@
+package realtime
+
+import (
+	"github.com/google/uuid"
+
+	"task-svc/internal/domain"
+)
+
+// Message types sent by clients
+const (
+	TypeSubscribe   = "subscribe"
+	TypeUnsubscribe = "unsubscribe"
+	TypeView        = "view"
+	TypeLeave       = "leave"
+)
+
+// Message types sent by the hub; typing, ping and pong are sent both ways
+const (
+	TypeEvent    = "event"
+	TypePresence = "presence"
+	TypeTyping   = "typing"
+	TypeReset    = "reset"
+	TypeAck      = "ack"
+	TypeError    = "error"
+	TypePing     = "ping"
+	TypePong     = "pong"
+)
+
+// Inbound is a message received from a client. Which fields are used
+// depends on the type:
+//   - subscribe: ID names the subscription, and either TaskIDs or Filter
+//     selects its events; subscribing again under an ID replaces it
+//   - unsubscribe: ID
+//   - view, leave: TaskID of the task the client opens or closes
+//   - typing: TaskID, and Typing, which defaults to true
+//   - ping, pong: nothing
+type Inbound struct {
+	Type    string      `json:"type"`
+	ID      string      `json:"id,omitempty"`
+	TaskIDs []uuid.UUID `json:"task_ids,omitempty"`
+	Filter  *Filter     `json:"filter,omitempty"`
+	TaskID  *uuid.UUID  `json:"task_id,omitempty"`
+	Typing  *bool       `json:"typing,omitempty"`
+}
+
+// Filter selects the events of a subscription by the status and project of their task
+type Filter struct {
+	Status    []domain.Status `json:"status,omitempty"`
+	ProjectID *uuid.UUID      `json:"project_id,omitempty"`
+}
+
+// EventMessage delivers a task event together with the client's
+// subscriptions it matched
+type EventMessage struct {
+	Type          string           `json:"type"`
+	Subscriptions []string         `json:"subscriptions"`
+	Event         domain.TaskEvent `json:"event"`
+}
+
+// PresenceMessage lists the users viewing a task. It is sent whenever the
+// list changes, to the task's viewers and the clients subscribed to it.
+type PresenceMessage struct {
+	Type    string      `json:"type"`
+	TaskID  uuid.UUID   `json:"task_id"`
+	Viewers []uuid.UUID `json:"viewers"`
+}
+
+// TypingMessage tells that a user started or stopped typing a comment on a task
+type TypingMessage struct {
+	Type   string    `json:"type"`
+	TaskID uuid.UUID `json:"task_id"`
+	UserID uuid.UUID `json:"user_id"`
+	Typing bool      `json:"typing"`
+}
+
+// AckMessage confirms a subscribe or unsubscribe request
+type AckMessage struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// ErrorMessage reports a request that could not be handled
+type ErrorMessage struct {
+	Type    string `json:"type"`
+	ID      string `json:"id,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ControlMessage is a message without a body: ping, pong, or reset, which
+// tells the client that events may have been missed and it should reload
+type ControlMessage struct {
+	Type string `json:"type"`
+}
@@ -0,0 +1,90 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"task-svc/internal/domain"
+)
+
+// Assignments returns every change of a task's assignee, oldest first
+func (r *TaskRepo) Assignments(ctx context.Context, taskID uuid.UUID) ([]domain.Assignment, error) {
+	query := `
+		SELECT id, task_id, previous_assignee_id, assignee_id, changed_by, created_at
+		FROM task_assignments
+		WHERE task_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("selecting assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := []domain.Assignment{}
+	for rows.Next() {
+		var a domain.Assignment
+		var previous, assignee, changedBy pgtype.UUID
+		if err := rows.Scan(&a.ID, &a.TaskID, &previous, &assignee, &changedBy, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning assignment row: %w", err)
+		}
+		a.PreviousAssigneeID = nullableUUID(previous)
+		a.AssigneeID = nullableUUID(assignee)
+		a.ChangedBy = nullableUUID(changedBy)
+		assignments = append(assignments, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating assignment rows: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// recordAssignment appends a reassignment to the task's assignment history
+// inside tx when the assignee actually changed. The acting user is taken from ctx.
+func recordAssignment(ctx context.Context, tx pgx.Tx, taskID uuid.UUID, previous, assignee *uuid.UUID) error {
+	if sameUUID(previous, assignee) {
+		return nil
+	}
+
+	var changedBy *uuid.UUID
+	if actor, ok := domain.ActorFromContext(ctx); ok {
+		changedBy = &actor
+	}
+
+	query := `
+		INSERT INTO task_assignments (id, task_id, previous_assignee_id, assignee_id, changed_by)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	if _, err := tx.Exec(ctx, query, uuid.New(), taskID, previous, assignee, changedBy); err != nil {
+		if isUserForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("recording assignment: %w", err)
+	}
+
+	return nil
+}
+
+// sameUUID reports whether two optional IDs are equal
+func sameUUID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// nullableUUID converts a nullable UUID column into an optional ID
+func nullableUUID(v pgtype.UUID) *uuid.UUID {
+	if !v.Valid {
+		return nil
+	}
+	id := uuid.UUID(v.Bytes)
+	return &id
+}
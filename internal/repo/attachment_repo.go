@@ -0,0 +1,172 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/db"
+)
+
+// ErrAttachmentNotFound is returned when an attachment does not exist on a task
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// attachmentColumns is the column list shared by every query that returns attachments
+const attachmentColumns = `id, task_id, filename, content_type, size, checksum_sha256, storage_key, uploaded_by, created_at`
+
+// AttachmentRepo handles database operations for attachment metadata
+type AttachmentRepo struct {
+	db *db.Pool
+}
+
+// NewAttachmentRepo creates a new attachment repository
+func NewAttachmentRepo(db *db.Pool) *AttachmentRepo {
+	return &AttachmentRepo{db: db}
+}
+
+// List returns a task's attachments, oldest first
+func (r *AttachmentRepo) List(ctx context.Context, taskID uuid.UUID) ([]domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE task_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("selecting attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []domain.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning attachment row: %w", err)
+		}
+		attachments = append(attachments, *attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating attachment rows: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// Get retrieves an attachment of a task by ID
+func (r *AttachmentRepo) Get(ctx context.Context, taskID, id uuid.UUID) (*domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE task_id = $1 AND id = $2`
+
+	attachment, err := scanAttachment(r.db.QueryRow(ctx, query, taskID, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("selecting attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// Create records an attachment and bumps the task's updated_at
+func (r *AttachmentRepo) Create(ctx context.Context, attachment *domain.Attachment) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO attachments (id, task_id, filename, content_type, size, checksum_sha256, storage_key, uploaded_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err = tx.Exec(ctx, query,
+		attachment.ID,
+		attachment.TaskID,
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.ChecksumSHA256,
+		attachment.StorageKey,
+		attachment.UploadedBy,
+		attachment.CreatedAt,
+	)
+	if err != nil {
+		if isUserForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		if isForeignKeyViolation(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("inserting attachment: %w", err)
+	}
+
+	if err := touchTask(ctx, tx, attachment.TaskID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing attachment insert: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes an attachment record and returns it, so that the caller can
+// remove the stored content as well
+func (r *AttachmentRepo) Delete(ctx context.Context, taskID, id uuid.UUID) (*domain.Attachment, error) {
+	query := `DELETE FROM attachments WHERE task_id = $1 AND id = $2 RETURNING ` + attachmentColumns
+
+	attachment, err := scanAttachment(r.db.QueryRow(ctx, query, taskID, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("deleting attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// deleteTaskAttachments removes the attachment records of the given tasks
+// inside tx and returns their storage keys, so that the stored contents can be
+// removed before the transaction commits
+func deleteTaskAttachments(ctx context.Context, tx pgx.Tx, taskIDs []uuid.UUID) ([]string, error) {
+	rows, err := tx.Query(ctx, `DELETE FROM attachments WHERE task_id = ANY($1) RETURNING storage_key`, taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("deleting task attachments: %w", err)
+	}
+
+	keys, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("collecting attachment keys: %w", err)
+	}
+	return keys, nil
+}
+
+// scanAttachment reads a row selected with attachmentColumns into a domain.Attachment
+func scanAttachment(row pgx.Row) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+	var uploadedBy pgtype.UUID
+
+	err := row.Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.Filename,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.ChecksumSHA256,
+		&attachment.StorageKey,
+		&uploadedBy,
+		&attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment.UploadedBy = nullableUUID(uploadedBy)
+
+	return &attachment, nil
+}
@@ -0,0 +1,274 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/db"
+)
+
+// Comment errors
+var (
+	ErrCommentNotFound       = errors.New("comment not found")
+	ErrCommentParentNotFound = errors.New("parent comment not found")
+)
+
+// commentColumns is the column list shared by every query that returns comments
+const commentColumns = `id, task_id, parent_id, author_id, body, created_at, updated_at, deleted_at, version`
+
+// CommentRepo handles database operations for task comments
+type CommentRepo struct {
+	db *db.Pool
+}
+
+// NewCommentRepo creates a new comment repository
+func NewCommentRepo(db *db.Pool) *CommentRepo {
+	return &CommentRepo{db: db}
+}
+
+// List returns a page of a task's comments, oldest first, starting after filter.After
+func (r *CommentRepo) List(ctx context.Context, taskID uuid.UUID, filter domain.CommentFilter) (*domain.CommentList, error) {
+	where := &whereBuilder{}
+	where.where("task_id = " + where.arg(taskID))
+	if filter.After != nil {
+		where.where("(created_at, id) > (" + where.arg(filter.After.CreatedAt) + ", " + where.arg(filter.After.ID) + ")")
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	query := `
+		SELECT ` + commentColumns + `
+		FROM comments
+		` + where.clause() + `
+		ORDER BY created_at, id
+		LIMIT ` + where.arg(filter.Limit+1)
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("selecting comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []domain.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning comment row: %w", err)
+		}
+		comments = append(comments, *comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating comment rows: %w", err)
+	}
+
+	list := &domain.CommentList{Items: comments}
+	if len(comments) > filter.Limit {
+		list.Items = comments[:filter.Limit]
+		last := list.Items[filter.Limit-1]
+		list.Next = &domain.CommentCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return list, nil
+}
+
+// Get retrieves a comment of a task by ID
+func (r *CommentRepo) Get(ctx context.Context, taskID, id uuid.UUID) (*domain.Comment, error) {
+	query := `SELECT ` + commentColumns + ` FROM comments WHERE task_id = $1 AND id = $2`
+
+	comment, err := scanComment(r.db.QueryRow(ctx, query, taskID, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("selecting comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// Create inserts a comment and bumps the task's updated_at. A reply must
+// answer a comment on the same task. A task in the trash is reported as
+// ErrNotFound.
+func (r *CommentRepo) Create(ctx context.Context, comment *domain.Comment) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Hold the task so that it is not moved to the trash meanwhile
+	var taskID uuid.UUID
+	err = tx.QueryRow(ctx, `SELECT id FROM tasks WHERE id = $1 AND deleted_at IS NULL FOR SHARE`, comment.TaskID).Scan(&taskID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("locking task: %w", err)
+	}
+
+	if comment.ParentID != nil {
+		var found bool
+		query := `SELECT EXISTS(SELECT 1 FROM comments WHERE id = $1 AND task_id = $2)`
+		if err := tx.QueryRow(ctx, query, *comment.ParentID, comment.TaskID).Scan(&found); err != nil {
+			return fmt.Errorf("checking parent comment: %w", err)
+		}
+		if !found {
+			return ErrCommentParentNotFound
+		}
+	}
+
+	query := `
+		INSERT INTO comments (id, task_id, parent_id, author_id, body, created_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err = tx.Exec(ctx, query,
+		comment.ID,
+		comment.TaskID,
+		comment.ParentID,
+		comment.AuthorID,
+		comment.Body,
+		comment.CreatedAt,
+		comment.UpdatedAt,
+		comment.Version,
+	)
+	if err != nil {
+		if isUserForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		if isForeignKeyViolation(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("inserting comment: %w", err)
+	}
+
+	if err := touchTask(ctx, tx, comment.TaskID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing comment insert: %w", err)
+	}
+
+	return nil
+}
+
+// Update saves an edited comment body with optimistic locking and bumps the
+// task's updated_at. Deleted comments cannot be edited.
+func (r *CommentRepo) Update(ctx context.Context, comment *domain.Comment) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE comments
+		SET body = $3, updated_at = now(), version = version + 1
+		WHERE task_id = $1 AND id = $2 AND version = $4 AND deleted_at IS NULL
+		RETURNING version, updated_at
+	`
+
+	err = tx.QueryRow(ctx, query,
+		comment.TaskID,
+		comment.ID,
+		comment.Body,
+		comment.Version,
+	).Scan(&comment.Version, &comment.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Tell a missing or deleted comment apart from a stale version
+			var live bool
+			check := `SELECT EXISTS(SELECT 1 FROM comments WHERE task_id = $1 AND id = $2 AND deleted_at IS NULL)`
+			if err := tx.QueryRow(ctx, check, comment.TaskID, comment.ID).Scan(&live); err != nil {
+				return fmt.Errorf("checking comment existence: %w", err)
+			}
+			if !live {
+				return ErrCommentNotFound
+			}
+			return ErrVersionConflict
+		}
+		return fmt.Errorf("updating comment: %w", err)
+	}
+
+	if err := touchTask(ctx, tx, comment.TaskID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing comment update: %w", err)
+	}
+
+	return nil
+}
+
+// Delete turns a comment into a tombstone, keeping its replies attached
+func (r *CommentRepo) Delete(ctx context.Context, taskID, id uuid.UUID) error {
+	query := `
+		UPDATE comments
+		SET body = '', deleted_at = now(), updated_at = now(), version = version + 1
+		WHERE task_id = $1 AND id = $2 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.Exec(ctx, query, taskID, id)
+	if err != nil {
+		return fmt.Errorf("deleting comment: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// touchTask moves a task's updated_at forward inside tx without bumping its
+// version, so that activity does not invalidate clients' optimistic locks
+func touchTask(ctx context.Context, tx pgx.Tx, taskID uuid.UUID) error {
+	if _, err := tx.Exec(ctx, `SELECT set_config('task_svc.activity_only', 'on', true)`); err != nil {
+		return fmt.Errorf("marking task activity: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `UPDATE tasks SET updated_at = now() WHERE id = $1`, taskID); err != nil {
+		return fmt.Errorf("touching task: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `SELECT set_config('task_svc.activity_only', 'off', true)`); err != nil {
+		return fmt.Errorf("clearing task activity: %w", err)
+	}
+	return nil
+}
+
+// scanComment reads a row selected with commentColumns into a domain.Comment
+func scanComment(row pgx.Row) (*domain.Comment, error) {
+	var comment domain.Comment
+	var parentID, authorID pgtype.UUID
+	var deletedAt pgtype.Timestamptz
+
+	err := row.Scan(
+		&comment.ID,
+		&comment.TaskID,
+		&parentID,
+		&authorID,
+		&comment.Body,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+		&deletedAt,
+		&comment.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.ParentID = nullableUUID(parentID)
+	comment.AuthorID = nullableUUID(authorID)
+	if deletedAt.Valid {
+		deleted := deletedAt.Time
+		comment.DeletedAt = &deleted
+	}
+
+	return &comment, nil
+}
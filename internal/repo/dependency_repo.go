@@ -0,0 +1,153 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"task-svc/internal/domain"
+)
+
+// Dependency errors
+var (
+	ErrDependencyCycle    = errors.New("dependency would create a cycle")
+	ErrDependencyExists   = errors.New("dependency already exists")
+	ErrDependencyNotFound = errors.New("dependency not found")
+)
+
+// dependencyLockKey serialises dependency inserts so that two concurrent
+// requests cannot each pass the cycle check and together close a loop.
+const dependencyLockKey = 7_238_401
+
+// AddDependency records that blockerID must be finished before blockedID can start.
+// Both tasks must exist, and the new edge must not close a cycle.
+func (r *TaskRepo) AddDependency(ctx context.Context, blockerID, blockedID uuid.UUID) (*domain.Dependency, error) {
+	if blockerID == blockedID {
+		return nil, ErrDependencyCycle
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, dependencyLockKey); err != nil {
+		return nil, fmt.Errorf("locking dependency graph: %w", err)
+	}
+
+	// Adding blocker -> blocked loops if blocked already (transitively) blocks blocker
+	cycleQuery := `
+		WITH RECURSIVE downstream AS (
+			SELECT blocked_id FROM task_dependencies WHERE blocker_id = $1
+			UNION
+			SELECT d.blocked_id FROM task_dependencies d JOIN downstream ds ON d.blocker_id = ds.blocked_id
+		)
+		SELECT EXISTS(SELECT 1 FROM downstream WHERE blocked_id = $2)
+	`
+	var cycle bool
+	if err := tx.QueryRow(ctx, cycleQuery, blockedID, blockerID).Scan(&cycle); err != nil {
+		return nil, fmt.Errorf("checking dependency cycle: %w", err)
+	}
+	if cycle {
+		return nil, ErrDependencyCycle
+	}
+
+	dep := domain.Dependency{BlockerID: blockerID, BlockedID: blockedID}
+	insertQuery := `
+		INSERT INTO task_dependencies (blocker_id, blocked_id)
+		VALUES ($1, $2)
+		RETURNING created_at
+	`
+	if err := tx.QueryRow(ctx, insertQuery, blockerID, blockedID).Scan(&dep.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDependencyExists
+		}
+		if isForeignKeyViolation(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("inserting dependency: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing dependency: %w", err)
+	}
+
+	return &dep, nil
+}
+
+// RemoveDependency deletes the edge between blockerID and blockedID
+func (r *TaskRepo) RemoveDependency(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	query := `DELETE FROM task_dependencies WHERE blocker_id = $1 AND blocked_id = $2`
+
+	result, err := r.db.Exec(ctx, query, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("deleting dependency: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrDependencyNotFound
+	}
+
+	return nil
+}
+
+// ListBlockers returns the tasks outside the trash that block the given task
+func (r *TaskRepo) ListBlockers(ctx context.Context, id uuid.UUID) ([]domain.Task, error) {
+	query := `
+		SELECT ` + selectTaskColumns("t") + `
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.blocker_id
+		WHERE d.blocked_id = $1 AND t.deleted_at IS NULL
+		ORDER BY d.created_at
+	`
+	return r.queryTasks(ctx, query, id)
+}
+
+// ListBlocked returns the tasks outside the trash that the given task blocks
+func (r *TaskRepo) ListBlocked(ctx context.Context, id uuid.UUID) ([]domain.Task, error) {
+	query := `
+		SELECT ` + selectTaskColumns("t") + `
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.blocked_id
+		WHERE d.blocker_id = $1 AND t.deleted_at IS NULL
+		ORDER BY d.created_at
+	`
+	return r.queryTasks(ctx, query, id)
+}
+
+// OpenBlockerIDs returns the IDs of blockers of the given task that are not yet
+// finished; blockers in the trash no longer block
+func (r *TaskRepo) OpenBlockerIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT t.id
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.blocker_id
+		JOIN statuses s ON s.name = t.status
+		WHERE d.blocked_id = $1 AND s.category <> 'done' AND t.deleted_at IS NULL
+		ORDER BY d.created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("selecting open blockers: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var blockerID uuid.UUID
+		if err := rows.Scan(&blockerID); err != nil {
+			return nil, fmt.Errorf("scanning open blocker: %w", err)
+		}
+		ids = append(ids, blockerID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating open blockers: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,137 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"task-svc/internal/domain"
+)
+
+// ErrVersionNotFound is returned when no version of a task with the given number was recorded
+var ErrVersionNotFound = errors.New("task version not found")
+
+// History returns every recorded version of a task, oldest first
+func (r *TaskRepo) History(ctx context.Context, taskID uuid.UUID) ([]domain.TaskHistoryEntry, error) {
+	query := `
+		SELECT task_id, version, action, restored_version, changed_by, changed_at, changes
+		FROM task_history
+		WHERE task_id = $1
+		ORDER BY version
+	`
+
+	rows, err := r.db.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("selecting task history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []domain.TaskHistoryEntry{}
+	for rows.Next() {
+		var e domain.TaskHistoryEntry
+		var changedBy pgtype.UUID
+		var changes []byte
+		if err := rows.Scan(&e.TaskID, &e.Version, &e.Action, &e.RestoredVersion, &changedBy, &e.ChangedAt, &changes); err != nil {
+			return nil, fmt.Errorf("scanning task history row: %w", err)
+		}
+		if err := json.Unmarshal(changes, &e.Changes); err != nil {
+			return nil, fmt.Errorf("decoding task history changes: %w", err)
+		}
+		e.ChangedBy = nullableUUID(changedBy)
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating task history rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Version returns a task as it was at the given version
+func (r *TaskRepo) Version(ctx context.Context, taskID uuid.UUID, version int) (*domain.Task, error) {
+	query := `SELECT snapshot FROM task_history WHERE task_id = $1 AND version = $2`
+
+	task, err := scanTaskSnapshot(r.db.QueryRow(ctx, query, taskID, version))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("selecting task version: %w", err)
+	}
+
+	return task, nil
+}
+
+// recordTaskVersion appends the current version of a task to its history
+// inside tx, together with the fields changed since the version recorded
+// before it. It must be called once per version, after the task row and its
+// labels have been written. restoredVersion names the version a revert
+// restored. The acting user is taken from ctx.
+func recordTaskVersion(ctx context.Context, tx pgx.Tx, action domain.HistoryAction, id uuid.UUID, restoredVersion *int) error {
+	query := `SELECT ` + selectTaskColumns("tasks") + ` FROM tasks WHERE id = $1`
+
+	task, err := scanTask(tx.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("selecting task for history: %w", err)
+	}
+
+	changes := []domain.FieldChange{}
+	if action != domain.HistoryCreated {
+		previousQuery := `SELECT snapshot FROM task_history WHERE task_id = $1 ORDER BY version DESC LIMIT 1`
+		previous, err := scanTaskSnapshot(tx.QueryRow(ctx, previousQuery, id))
+		switch {
+		case err == nil:
+			changes = domain.DiffTasks(*previous, *task)
+		case !errors.Is(err, pgx.ErrNoRows):
+			return fmt.Errorf("selecting previous task version: %w", err)
+		}
+	}
+
+	var changedBy *uuid.UUID
+	if actor, ok := domain.ActorFromContext(ctx); ok {
+		changedBy = &actor
+	}
+
+	encodedChanges, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("encoding task changes: %w", err)
+	}
+	snapshot, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("encoding task snapshot: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO task_history (task_id, version, action, restored_version, changed_by, changes, snapshot)
+		VALUES ($1, $2, $3, $4, $5, $6::jsonb, $7::jsonb)
+	`
+	if _, err := tx.Exec(ctx, insertQuery, task.ID, task.Version, string(action), restoredVersion, changedBy, string(encodedChanges), string(snapshot)); err != nil {
+		return fmt.Errorf("recording task version: %w", err)
+	}
+
+	return nil
+}
+
+// scanTaskSnapshot reads a task snapshot column into a domain.Task
+func scanTaskSnapshot(row pgx.Row) (*domain.Task, error) {
+	var snapshot []byte
+	if err := row.Scan(&snapshot); err != nil {
+		return nil, err
+	}
+
+	var task domain.Task
+	if err := json.Unmarshal(snapshot, &task); err != nil {
+		return nil, fmt.Errorf("decoding task snapshot: %w", err)
+	}
+
+	return &task, nil
+}
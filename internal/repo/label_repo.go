@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/db"
+)
+
+// Label errors
+var (
+	ErrLabelNotFound = errors.New("label not found")
+	ErrLabelExists   = errors.New("label already exists")
+)
+
+// labelColumns is the column list shared by every query that returns labels
+const labelColumns = `id, name, color, description, created_at, updated_at`
+
+// LabelRepo handles database operations for labels
+type LabelRepo struct {
+	db *db.Pool
+}
+
+// NewLabelRepo creates a new label repository
+func NewLabelRepo(db *db.Pool) *LabelRepo {
+	return &LabelRepo{db: db}
+}
+
+// List returns every label ordered by name
+func (r *LabelRepo) List(ctx context.Context) ([]domain.Label, error) {
+	query := `SELECT ` + labelColumns + ` FROM labels ORDER BY name`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("selecting labels: %w", err)
+	}
+	defer rows.Close()
+
+	labels := []domain.Label{}
+	for rows.Next() {
+		label, err := scanLabel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning label row: %w", err)
+		}
+		labels = append(labels, *label)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating label rows: %w", err)
+	}
+
+	return labels, nil
+}
+
+// Get retrieves a label by ID
+func (r *LabelRepo) Get(ctx context.Context, id uuid.UUID) (*domain.Label, error) {
+	query := `SELECT ` + labelColumns + ` FROM labels WHERE id = $1`
+
+	label, err := scanLabel(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, fmt.Errorf("selecting label: %w", err)
+	}
+
+	return label, nil
+}
+
+// Create inserts a new label
+func (r *LabelRepo) Create(ctx context.Context, label *domain.Label) error {
+	query := `
+		INSERT INTO labels (id, name, color, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		label.ID,
+		label.Name,
+		label.Color,
+		label.Description,
+		label.CreatedAt,
+		label.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrLabelExists
+		}
+		return fmt.Errorf("inserting label: %w", err)
+	}
+
+	return nil
+}
+
+// Update saves every field of an existing label
+func (r *LabelRepo) Update(ctx context.Context, label *domain.Label) error {
+	query := `
+		UPDATE labels
+		SET name = $2, color = $3, description = $4, updated_at = now()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		label.ID,
+		label.Name,
+		label.Color,
+		label.Description,
+	).Scan(&label.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrLabelNotFound
+		}
+		if isUniqueViolation(err) {
+			return ErrLabelExists
+		}
+		return fmt.Errorf("updating label: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a label and detaches it from every task
+func (r *LabelRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM labels WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("deleting label: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrLabelNotFound
+	}
+
+	return nil
+}
+
+// scanLabel reads a row selected with labelColumns into a domain.Label
+func scanLabel(row pgx.Row) (*domain.Label, error) {
+	var label domain.Label
+	var description pgtype.Text
+
+	err := row.Scan(
+		&label.ID,
+		&label.Name,
+		&label.Color,
+		&description,
+		&label.CreatedAt,
+		&label.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if description.Valid {
+		desc := description.String
+		label.Description = &desc
+	}
+
+	return &label, nil
+}
@@ -0,0 +1,267 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/db"
+)
+
+// outboxLockName identifies the advisory lock that serializes relay runs
+// across replicas, keeping events in order
+const outboxLockName = "task-svc.outbox"
+
+// OutboxChannel is the notification channel on which the seq of every event
+// written to the outbox is announced once its transaction commits
+const OutboxChannel = "task_events"
+
+// ErrEventNotFound is returned when an outbox event does not exist or has been purged
+var ErrEventNotFound = errors.New("event not found")
+
+// OutboxRepo handles database operations for the event outbox
+type OutboxRepo struct {
+	db *db.Pool
+}
+
+// NewOutboxRepo creates a new outbox repository
+func NewOutboxRepo(db *db.Pool) *OutboxRepo {
+	return &OutboxRepo{db: db}
+}
+
+// Relay hands up to limit unpublished events, oldest first, to publish and
+// marks the first n it reports as published. Only one replica relays at a
+// time: when another holds the relay lock, publish is not called and nothing
+// is relayed.
+func (r *OutboxRepo) Relay(ctx context.Context, limit int, publish func(context.Context, []domain.TaskEvent) int) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock(hashtext($1))`, outboxLockName).Scan(&locked); err != nil {
+		return 0, fmt.Errorf("acquiring outbox lock: %w", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	query := `
+		SELECT seq, payload
+		FROM outbox_events
+		WHERE published_at IS NULL
+		ORDER BY seq
+		LIMIT $1
+	`
+
+	events, err := queryOutboxEvents(ctx, tx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	n := min(max(publish(ctx, events), 0), len(events))
+	if n == 0 {
+		return 0, nil
+	}
+
+	seqs := make([]int64, n)
+	for i := range seqs {
+		seqs[i] = events[i].Seq
+	}
+	markQuery := `UPDATE outbox_events SET published_at = now() WHERE seq = ANY($1)`
+	if _, err := tx.Exec(ctx, markQuery, seqs); err != nil {
+		return 0, fmt.Errorf("marking outbox events published: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return n, nil
+}
+
+// PurgePublished deletes the events published before the given time and
+// returns how many were deleted
+func (r *OutboxRepo) PurgePublished(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM outbox_events WHERE published_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("purging outbox events: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// Event retrieves an event by its seq
+func (r *OutboxRepo) Event(ctx context.Context, seq int64) (*domain.TaskEvent, error) {
+	event, err := scanOutboxEvent(r.db.QueryRow(ctx, `SELECT seq, payload FROM outbox_events WHERE seq = $1`, seq))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEventNotFound
+		}
+		return nil, fmt.Errorf("selecting outbox event: %w", err)
+	}
+
+	return event, nil
+}
+
+// Recent retrieves the latest events after the given seq, at most limit of
+// them, oldest first
+func (r *OutboxRepo) Recent(ctx context.Context, after int64, limit int) ([]domain.TaskEvent, error) {
+	query := `
+		SELECT seq, payload FROM (
+			SELECT seq, payload FROM outbox_events WHERE seq > $1 ORDER BY seq DESC LIMIT $2
+		) recent
+		ORDER BY seq
+	`
+
+	return queryOutboxEvents(ctx, r.db, query, after, limit)
+}
+
+// Listen subscribes to OutboxChannel on a dedicated connection, calls
+// listening once the subscription is in place and then notify with the seq of
+// every event announced, until ctx is done or the connection fails
+func (r *OutboxRepo) Listen(ctx context.Context, listening func(), notify func(seq int64)) error {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring connection: %w", err)
+	}
+	// The connection may still be listening, so it must not go back to the pool
+	defer conn.Hijack().Close(context.Background())
+
+	if _, err := conn.Exec(ctx, `LISTEN `+OutboxChannel); err != nil {
+		return fmt.Errorf("listening for events: %w", err)
+	}
+	listening()
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("waiting for events: %w", err)
+		}
+		seq, err := strconv.ParseInt(notification.Payload, 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing event notification %q: %w", notification.Payload, err)
+		}
+		notify(seq)
+	}
+}
+
+// recordTaskEvent writes an event about the task with the given ID to the
+// outbox inside tx. The task is read back within tx, so the event carries it
+// exactly as the transaction leaves it.
+func recordTaskEvent(ctx context.Context, tx pgx.Tx, eventType domain.TaskEventType, id uuid.UUID, previousStatus *domain.Status) error {
+	query := `SELECT ` + selectTaskColumns("tasks") + ` FROM tasks WHERE id = $1`
+
+	task, err := scanTask(tx.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("selecting task for event: %w", err)
+	}
+
+	event := domain.NewTaskEvent(eventType, *task)
+	event.PreviousStatus = previousStatus
+	return insertOutboxEvent(ctx, tx, event)
+}
+
+// recordReminderEvent writes the event of a fired reminder to the outbox inside tx
+func recordReminderEvent(ctx context.Context, tx pgx.Tx, reminder domain.Reminder) error {
+	query := `SELECT ` + selectTaskColumns("tasks") + ` FROM tasks WHERE id = $1`
+
+	task, err := scanTask(tx.QueryRow(ctx, query, reminder.TaskID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("selecting task for event: %w", err)
+	}
+
+	event := domain.NewTaskEvent(reminder.EventType(), *task)
+	event.OccurredAt = reminder.FiredAt
+	event.Threshold = reminder.Threshold
+	return insertOutboxEvent(ctx, tx, event)
+}
+
+// recordTaskChange writes the events of an update to the outbox inside tx:
+// task.updated, followed by task.status_changed when the status moved away
+// from previousStatus
+func recordTaskChange(ctx context.Context, tx pgx.Tx, id uuid.UUID, previousStatus, status domain.Status) error {
+	if err := recordTaskEvent(ctx, tx, domain.EventTaskUpdated, id, nil); err != nil {
+		return err
+	}
+	if status == previousStatus {
+		return nil
+	}
+	return recordTaskEvent(ctx, tx, domain.EventTaskStatusChanged, id, &previousStatus)
+}
+
+// insertOutboxEvent writes an event to the outbox inside tx
+func insertOutboxEvent(ctx context.Context, tx pgx.Tx, event domain.TaskEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding %s event: %w", event.Type, err)
+	}
+
+	query := `
+		INSERT INTO outbox_events (id, aggregate_id, event_type, payload, occurred_at)
+		VALUES ($1, $2, $3, $4::jsonb, $5)
+	`
+	if _, err := tx.Exec(ctx, query, event.ID, event.Task.ID, string(event.Type), string(payload), event.OccurredAt); err != nil {
+		return fmt.Errorf("recording %s event: %w", event.Type, err)
+	}
+
+	return nil
+}
+
+// queryOutboxEvents runs a query selecting seq and payload and decodes the events
+func queryOutboxEvents(ctx context.Context, q querier, query string, args ...any) ([]domain.TaskEvent, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("selecting outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []domain.TaskEvent{}
+	for rows.Next() {
+		event, err := scanOutboxEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning outbox event row: %w", err)
+		}
+		events = append(events, *event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating outbox event rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// scanOutboxEvent reads a row of seq and payload into a domain.TaskEvent
+func scanOutboxEvent(row pgx.Row) (*domain.TaskEvent, error) {
+	var event domain.TaskEvent
+	var payload []byte
+	if err := row.Scan(&event.Seq, &payload); err != nil {
+		return nil, err
+	}
+	seq := event.Seq
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decoding outbox event %d: %w", seq, err)
+	}
+	event.Seq = seq
+
+	return &event, nil
+}
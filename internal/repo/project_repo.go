@@ -0,0 +1,212 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/db"
+)
+
+// Project errors
+var (
+	ErrProjectNotFound = errors.New("project not found")
+	ErrProjectExists   = errors.New("project already exists")
+	ErrProjectArchived = errors.New("project is archived")
+	ErrProjectNotEmpty = errors.New("project still has tasks")
+)
+
+// projectColumns is the column list shared by every query that returns projects
+const projectColumns = `id, key, name, description, archived, created_at, updated_at`
+
+// ProjectRepo handles database operations for projects
+type ProjectRepo struct {
+	db *db.Pool
+}
+
+// NewProjectRepo creates a new project repository
+func NewProjectRepo(db *db.Pool) *ProjectRepo {
+	return &ProjectRepo{db: db}
+}
+
+// List returns projects ordered by key, leaving out archived ones unless asked for
+func (r *ProjectRepo) List(ctx context.Context, includeArchived bool) ([]domain.Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE $1 OR NOT archived ORDER BY key`
+
+	rows, err := r.db.Query(ctx, query, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("selecting projects: %w", err)
+	}
+	defer rows.Close()
+
+	projects := []domain.Project{}
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning project row: %w", err)
+		}
+		projects = append(projects, *project)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating project rows: %w", err)
+	}
+
+	return projects, nil
+}
+
+// Get retrieves a project by ID
+func (r *ProjectRepo) Get(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	return r.getBy(ctx, "id", id)
+}
+
+// GetByKey retrieves a project by its key
+func (r *ProjectRepo) GetByKey(ctx context.Context, key string) (*domain.Project, error) {
+	return r.getBy(ctx, "key", key)
+}
+
+// Create inserts a new project
+func (r *ProjectRepo) Create(ctx context.Context, project *domain.Project) error {
+	query := `
+		INSERT INTO projects (id, key, name, description, archived, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		project.ID,
+		project.Key,
+		project.Name,
+		project.Description,
+		project.Archived,
+		project.CreatedAt,
+		project.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrProjectExists
+		}
+		return fmt.Errorf("inserting project: %w", err)
+	}
+
+	return nil
+}
+
+// Update saves the mutable fields of an existing project
+func (r *ProjectRepo) Update(ctx context.Context, project *domain.Project) error {
+	query := `
+		UPDATE projects
+		SET name = $2, description = $3, archived = $4, updated_at = now()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		project.ID,
+		project.Name,
+		project.Description,
+		project.Archived,
+	).Scan(&project.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrProjectNotFound
+		}
+		return fmt.Errorf("updating project: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a project. Projects that still contain tasks are refused.
+func (r *ProjectRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM projects WHERE id = $1`, id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrProjectNotEmpty
+		}
+		return fmt.Errorf("deleting project: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrProjectNotFound
+	}
+
+	return nil
+}
+
+// getBy retrieves a single project matching column = value
+func (r *ProjectRepo) getBy(ctx context.Context, column string, value any) (*domain.Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE ` + column + ` = $1`
+
+	project, err := scanProject(r.db.QueryRow(ctx, query, value))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, fmt.Errorf("selecting project: %w", err)
+	}
+
+	return project, nil
+}
+
+// nextTaskNumber reserves the next task number of a project inside tx and
+// returns it with the project key. The row lock taken by the UPDATE keeps
+// numbers sequential and unique across concurrent inserts.
+func nextTaskNumber(ctx context.Context, tx pgx.Tx, projectID uuid.UUID) (int, string, error) {
+	query := `
+		UPDATE projects
+		SET next_number = next_number + 1
+		WHERE id = $1 AND NOT archived
+		RETURNING next_number - 1, key
+	`
+
+	var number int
+	var key string
+	err := tx.QueryRow(ctx, query, projectID).Scan(&number, &key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			var archived bool
+			err := tx.QueryRow(ctx, `SELECT archived FROM projects WHERE id = $1`, projectID).Scan(&archived)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return 0, "", ErrProjectNotFound
+			}
+			if err != nil {
+				return 0, "", fmt.Errorf("checking project: %w", err)
+			}
+			return 0, "", ErrProjectArchived
+		}
+		return 0, "", fmt.Errorf("reserving task number: %w", err)
+	}
+
+	return number, key, nil
+}
+
+// scanProject reads a row selected with projectColumns into a domain.Project
+func scanProject(row pgx.Row) (*domain.Project, error) {
+	var project domain.Project
+	var description pgtype.Text
+
+	err := row.Scan(
+		&project.ID,
+		&project.Key,
+		&project.Name,
+		&description,
+		&project.Archived,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if description.Valid {
+		desc := description.String
+		project.Description = &desc
+	}
+
+	return &project, nil
+}
@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// querier is implemented by both the connection pool and transactions
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// whereBuilder accumulates AND-ed conditions together with their positional
+// arguments so optional filters can be composed without string-formatting values.
+type whereBuilder struct {
+	conds []string
+	args  []any
+}
+
+// arg registers a query argument and returns its placeholder
+func (b *whereBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+// where adds a condition; placeholders must come from arg
+func (b *whereBuilder) where(cond string) {
+	b.conds = append(b.conds, cond)
+}
+
+// clause returns the WHERE clause, or an empty string when there are no conditions
+func (b *whereBuilder) clause() string {
+	if len(b.conds) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(b.conds, " AND ")
+}
+
+// qualifyColumns prefixes every column of a comma-separated column list with
+// a table alias, for queries where the bare names would be ambiguous
+func qualifyColumns(alias, columns string) string {
+	names := strings.Split(columns, ", ")
+	for i, name := range names {
+		names[i] = alias + "." + name
+	}
+	return strings.Join(names, ", ")
+}
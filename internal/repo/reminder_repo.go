@@ -0,0 +1,120 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/db"
+)
+
+// reminderLockName identifies the advisory lock that serializes reminder scans
+// across replicas
+const reminderLockName = "task-svc.reminders"
+
+// ReminderRepo handles database operations for task reminders
+type ReminderRepo struct {
+	db *db.Pool
+}
+
+// NewReminderRepo creates a new reminder repository
+func NewReminderRepo(db *db.Pool) *ReminderRepo {
+	return &ReminderRepo{db: db}
+}
+
+// ClaimDue records up to limit reminders that are due at now and have not
+// fired yet, and returns them. Tasks in a done-category status are skipped, as
+// are lead thresholds whose due date has already passed. Each claimed reminder
+// writes a task.reminder or task.overdue event to the outbox in the same
+// transaction, so the event exists exactly once per task, threshold and due
+// date. Only one replica claims at a time: when another holds the scan lock,
+// nothing is claimed and ok is false.
+func (r *ReminderRepo) ClaimDue(ctx context.Context, thresholds []domain.ReminderThreshold, now time.Time, limit int) (reminders []domain.Reminder, ok bool, err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock(hashtext($1))`, reminderLockName).Scan(&locked); err != nil {
+		return nil, false, fmt.Errorf("acquiring reminder lock: %w", err)
+	}
+	if !locked {
+		return nil, false, nil
+	}
+
+	names := make([]string, len(thresholds))
+	leads := make([]int64, len(thresholds))
+	for i, t := range thresholds {
+		names[i] = t.Name
+		leads[i] = int64(t.Lead / time.Second)
+	}
+
+	query := `
+		WITH thresholds (name, lead) AS (
+			SELECT name, make_interval(secs => lead)
+			FROM unnest($1::text[], $2::bigint[]) AS u (name, lead)
+		), claimed AS (
+			INSERT INTO task_reminders (task_id, threshold, due_date, fired_at)
+			SELECT t.id, th.name, t.due_date, $3::timestamptz
+			FROM tasks t
+			JOIN statuses s ON s.name = t.status
+			CROSS JOIN thresholds th
+			WHERE t.due_date IS NOT NULL
+				AND t.deleted_at IS NULL
+				AND t.archived_at IS NULL
+				AND s.category <> 'done'
+				AND t.due_date - th.lead <= $3
+				AND (th.lead = interval '0' OR t.due_date > $3)
+				AND NOT EXISTS (
+					SELECT 1 FROM task_reminders r
+					WHERE r.task_id = t.id AND r.threshold = th.name AND r.due_date = t.due_date
+				)
+			ORDER BY t.due_date, t.id
+			LIMIT $4
+			ON CONFLICT DO NOTHING
+			RETURNING task_id, threshold, due_date, fired_at
+		)
+		SELECT c.task_id, t.title, t.assignee_id, c.threshold, c.due_date, c.fired_at
+		FROM claimed c
+		JOIN tasks t ON t.id = c.task_id
+		ORDER BY c.due_date, c.task_id
+	`
+
+	rows, err := tx.Query(ctx, query, names, leads, now, limit)
+	if err != nil {
+		return nil, false, fmt.Errorf("claiming reminders: %w", err)
+	}
+	defer rows.Close()
+
+	reminders = []domain.Reminder{}
+	for rows.Next() {
+		var reminder domain.Reminder
+		var assignee pgtype.UUID
+		if err := rows.Scan(&reminder.TaskID, &reminder.Title, &assignee, &reminder.Threshold, &reminder.DueDate, &reminder.FiredAt); err != nil {
+			return nil, false, fmt.Errorf("scanning reminder row: %w", err)
+		}
+		reminder.AssigneeID = nullableUUID(assignee)
+		reminders = append(reminders, reminder)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("iterating reminder rows: %w", err)
+	}
+
+	for _, reminder := range reminders {
+		if err := recordReminderEvent(ctx, tx, reminder); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return reminders, true, nil
+}
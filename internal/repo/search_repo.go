@@ -0,0 +1,127 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"task-svc/internal/domain"
+)
+
+// Highlighting options for ts_headline: titles are short enough to be shown
+// whole, descriptions are cut down to the fragments around the matches
+const (
+	titleHeadlineOptions   = `HighlightAll=true, StartSel=<mark>, StopSel=</mark>`
+	snippetHeadlineOptions = `StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=25, MinWords=10, FragmentDelimiter=" … "`
+)
+
+// Search retrieves a page of the tasks matching filter.Search, most relevant
+// first, with the matches in their title and description highlighted. Hits
+// ranked equally follow the filter's sort. filter.Search must be set.
+func (r *TaskRepo) Search(ctx context.Context, filter domain.TaskFilter) (*domain.TaskSearchResults, error) {
+	where := taskListWhere(filter)
+
+	countQuery := `SELECT count(*) FROM tasks ` + where.clause()
+
+	var total int
+	if err := r.db.QueryRow(ctx, countQuery, where.args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("counting tasks: %w", err)
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(filter.Size)))
+	meta := domain.PageMeta{
+		Page:       filter.Page,
+		PageSize:   filter.Size,
+		TotalItems: &total,
+		TotalPages: &totalPages,
+	}
+	if total == 0 {
+		return &domain.TaskSearchResults{Items: []domain.TaskSearchHit{}, Meta: meta}, nil
+	}
+
+	searchQuery := where.arg(searchTSQuery(*filter.Search))
+	query := `
+		SELECT ` + selectTaskColumns("tasks") + `,
+			ts_rank_cd(search_vector, search_query) AS rank,
+			ts_headline('english', title, search_query, '` + titleHeadlineOptions + `'),
+			CASE WHEN description IS NOT NULL
+				THEN ts_headline('english', description, search_query, '` + snippetHeadlineOptions + `')
+			END
+		FROM tasks, to_tsquery('english', ` + searchQuery + `) AS search_query
+		` + where.clause() + `
+		ORDER BY rank DESC, ` + strings.Join(taskOrderTerms(filter.Sort), ", ") + `
+		LIMIT ` + where.arg(filter.Size) + ` OFFSET ` + where.arg((filter.Page-1)*filter.Size)
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("searching tasks: %w", err)
+	}
+	defer rows.Close()
+
+	hits := []domain.TaskSearchHit{}
+	for rows.Next() {
+		var hit domain.TaskSearchHit
+		task, err := scanTask(searchHitRow{rows: rows, hit: &hit})
+		if err != nil {
+			return nil, fmt.Errorf("scanning search hit: %w", err)
+		}
+		hit.Task = *task
+		hits = append(hits, hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating search hits: %w", err)
+	}
+
+	return &domain.TaskSearchResults{Items: hits, Meta: meta}, nil
+}
+
+// searchHitRow lets scanTask read a search result row, scanning the rank and
+// highlights that follow the task's columns into hit
+type searchHitRow struct {
+	rows pgx.Rows
+	hit  *domain.TaskSearchHit
+}
+
+// Scan implements pgx.Row
+func (r searchHitRow) Scan(dest ...any) error {
+	return r.rows.Scan(append(dest, &r.hit.Rank, &r.hit.TitleHighlight, &r.hit.Snippet)...)
+}
+
+// searchTSQuery compiles a search into to_tsquery syntax. Every word is
+// quoted, and words hold only letters and digits, so user input cannot
+// change the structure of the query.
+func searchTSQuery(q domain.SearchQuery) string {
+	groups := make([]string, 0, len(q.Groups))
+	for _, group := range q.Groups {
+		terms := make([]string, 0, len(group))
+		for _, term := range group {
+			words := make([]string, len(term.Words))
+			for i, w := range term.Words {
+				words[i] = "'" + w + "'"
+			}
+			if term.Prefix {
+				words[len(words)-1] += ":*"
+			}
+			expr := strings.Join(words, " <-> ")
+			if len(words) > 1 {
+				expr = "(" + expr + ")"
+			}
+			if term.Negated {
+				expr = "!" + expr
+			}
+			terms = append(terms, expr)
+		}
+		groups = append(groups, strings.Join(terms, " & "))
+	}
+	if len(groups) == 1 {
+		return groups[0]
+	}
+	for i, g := range groups {
+		groups[i] = "(" + g + ")"
+	}
+	return strings.Join(groups, " | ")
+}
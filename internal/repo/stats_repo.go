@@ -0,0 +1,165 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"task-svc/internal/domain"
+)
+
+// statsWeekExpr names the week, by its Monday in UTC, of a timestamp column
+const statsWeekExpr = `to_char(date_trunc('week', %s AT TIME ZONE 'UTC'), 'YYYY-MM-DD')`
+
+// Stats counts the tasks matching filter, grouped by the dimensions of
+// groupBy, in one aggregate query. Overdue tasks and due buckets are judged
+// as of asOf. Groups are ordered by their keys, dimension by dimension, with
+// missing values last; filter's sort and paging are ignored.
+func (r *TaskRepo) Stats(ctx context.Context, filter domain.TaskFilter, groupBy []domain.StatsDimension, asOf time.Time) (*domain.TaskStats, error) {
+	where := taskListWhere(filter)
+	now := where.arg(asOf) + "::timestamptz"
+
+	var keys, groupTerms, orderTerms []string
+	joinLabels := false
+	for i, d := range groupBy {
+		key, order := statsDimensionExprs(d, now)
+		if d == domain.StatsByLabel {
+			joinLabels = true
+		}
+		k, o := "k"+strconv.Itoa(i), "o"+strconv.Itoa(i)
+		keys = append(keys, key+" AS "+k, order+" AS "+o)
+		groupTerms = append(groupTerms, k, o)
+		orderTerms = append(orderTerms, o, k)
+	}
+
+	// A task under several labels is joined once per label, so it is counted
+	// distinctly to keep the total right
+	count := "count(*)"
+	from := `(SELECT id, status, priority, severity, assignee_id, due_date, created_at, completed_at FROM tasks ` + where.clause() + `) t`
+	if joinLabels {
+		count = "count(DISTINCT id)"
+		from += `
+			LEFT JOIN task_labels tl ON tl.task_id = t.id
+			LEFT JOIN labels l ON l.id = tl.label_id`
+	}
+
+	selects := append(prefixTerms("k", len(groupBy)),
+		count,
+		count+` FILTER (WHERE completed_at IS NOT NULL)`,
+		count+` FILTER (WHERE completed_at IS NULL AND due_date < `+now+`)`,
+	)
+	grouping := ""
+	if len(groupBy) > 0 {
+		selects = append(selects, "GROUPING(k0)")
+		grouping = `
+			GROUP BY GROUPING SETS ((` + strings.Join(groupTerms, ", ") + `), ())
+			ORDER BY ` + strings.Join(orderTerms, ", ")
+	}
+
+	query := `
+		SELECT ` + strings.Join(selects, ", ") + `
+		FROM (
+			SELECT ` + strings.Join(append([]string{"t.id", "t.due_date", "t.completed_at"}, keys...), ", ") + `
+			FROM ` + from + `
+		) keyed` + grouping
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("counting tasks: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &domain.TaskStats{GroupBy: groupBy, Groups: []domain.TaskStatsGroup{}, AsOf: asOf}
+	for rows.Next() {
+		values := make([]pgtype.Text, len(groupBy))
+		var counts domain.TaskCounts
+		var isTotal int
+
+		dest := make([]any, 0, len(groupBy)+4)
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		dest = append(dest, &counts.Tasks, &counts.Done, &counts.Overdue)
+		if len(groupBy) > 0 {
+			dest = append(dest, &isTotal)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scanning task counts: %w", err)
+		}
+
+		// The empty grouping set counts every matching task
+		if len(groupBy) > 0 && isTotal != 0 {
+			stats.Total = counts
+			continue
+		}
+		if len(groupBy) == 0 {
+			stats.Total = counts
+		}
+
+		group := domain.TaskStatsGroup{Keys: make([]*string, len(values)), TaskCounts: counts}
+		for i, v := range values {
+			if v.Valid {
+				key := v.String
+				group.Keys[i] = &key
+			}
+		}
+		stats.Groups = append(stats.Groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating task counts: %w", err)
+	}
+
+	return stats, nil
+}
+
+// statsDimensionExprs returns the text expression grouping tasks by a
+// dimension, over a task t and its label l, and the expression ordering the
+// groups. now is the SQL time the due buckets are measured from.
+func statsDimensionExprs(d domain.StatsDimension, now string) (key, order string) {
+	switch d {
+	case domain.StatsByPriority:
+		return "t.priority::text", taskPriorityRankExpr
+	case domain.StatsBySeverity:
+		return "t.severity::text", taskSeverityRankExpr
+	case domain.StatsByAssignee:
+		return "t.assignee_id::text", "t.assignee_id::text"
+	case domain.StatsByLabel:
+		return "l.name", "l.name"
+	case domain.StatsByDueBucket:
+		key = `CASE
+			WHEN t.due_date IS NULL THEN '` + string(domain.DueNone) + `'
+			WHEN t.due_date < ` + now + ` THEN '` + string(domain.DueOverdue) + `'
+			WHEN t.due_date < ` + now + ` + interval '1 day' THEN '` + string(domain.DueWithin1d) + `'
+			WHEN t.due_date < ` + now + ` + interval '7 days' THEN '` + string(domain.DueWithin7d) + `'
+			WHEN t.due_date < ` + now + ` + interval '30 days' THEN '` + string(domain.DueWithin30d) + `'
+			ELSE '` + string(domain.DueLater) + `'
+		END`
+		names := make([]string, len(domain.DueBuckets))
+		for i, b := range domain.DueBuckets {
+			names[i] = "'" + string(b) + "'"
+		}
+		return key, "array_position(ARRAY[" + strings.Join(names, ", ") + "]::text[], " + key + ")"
+	case domain.StatsByCreatedWeek:
+		key = fmt.Sprintf(statsWeekExpr, "t.created_at")
+		return key, key
+	case domain.StatsByCompletedWeek:
+		key = fmt.Sprintf(statsWeekExpr, "t.completed_at")
+		return key, key
+	default:
+		return "t.status", "t.status"
+	}
+}
+
+// prefixTerms lists the column names prefix0 to prefix(n-1)
+func prefixTerms(prefix string, n int) []string {
+	terms := make([]string, n)
+	for i := range terms {
+		terms[i] = prefix + strconv.Itoa(i)
+	}
+	return terms
+}
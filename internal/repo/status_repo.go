@@ -0,0 +1,184 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/db"
+)
+
+// Status errors
+var (
+	ErrStatusNotFound = errors.New("status not found")
+	ErrStatusExists   = errors.New("status already exists")
+	ErrStatusInUse    = errors.New("status is used by tasks")
+)
+
+// statusColumns is the column list shared by every query that returns statuses
+const statusColumns = `id, project_id, name, category, position, color, created_at, updated_at`
+
+// StatusRepo handles database operations for the configurable status set
+type StatusRepo struct {
+	db *db.Pool
+}
+
+// NewStatusRepo creates a new status repository
+func NewStatusRepo(db *db.Pool) *StatusRepo {
+	return &StatusRepo{db: db}
+}
+
+// List returns every status, of the default set and of all projects, ordered
+// by position
+func (r *StatusRepo) List(ctx context.Context) (domain.StatusSet, error) {
+	query := `SELECT ` + statusColumns + ` FROM statuses ORDER BY position, name`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("selecting statuses: %w", err)
+	}
+	defer rows.Close()
+
+	statuses := domain.StatusSet{}
+	for rows.Next() {
+		status, err := scanStatus(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning status row: %w", err)
+		}
+		statuses = append(statuses, *status)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating status rows: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// Get retrieves a status by ID
+func (r *StatusRepo) Get(ctx context.Context, id uuid.UUID) (*domain.StatusDefinition, error) {
+	query := `SELECT ` + statusColumns + ` FROM statuses WHERE id = $1`
+
+	status, err := scanStatus(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStatusNotFound
+		}
+		return nil, fmt.Errorf("selecting status: %w", err)
+	}
+
+	return status, nil
+}
+
+// Create inserts a new status
+func (r *StatusRepo) Create(ctx context.Context, status *domain.StatusDefinition) error {
+	query := `
+		INSERT INTO statuses (id, project_id, name, category, position, color, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		status.ID,
+		status.ProjectID,
+		status.Name,
+		status.Category,
+		status.Position,
+		status.Color,
+		status.CreatedAt,
+		status.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrStatusExists
+		}
+		if isForeignKeyViolation(err) {
+			return ErrProjectNotFound
+		}
+		return fmt.Errorf("inserting status: %w", err)
+	}
+
+	return nil
+}
+
+// Update saves every field of an existing status but its project. Renaming a status renames it
+// on all tasks through the ON UPDATE CASCADE foreign key.
+func (r *StatusRepo) Update(ctx context.Context, status *domain.StatusDefinition) error {
+	query := `
+		UPDATE statuses
+		SET name = $2, category = $3, position = $4, color = $5, updated_at = now()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		status.ID,
+		status.Name,
+		status.Category,
+		status.Position,
+		status.Color,
+	).Scan(&status.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrStatusNotFound
+		}
+		if isUniqueViolation(err) {
+			return ErrStatusExists
+		}
+		return fmt.Errorf("updating status: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a status that no task uses
+func (r *StatusRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM statuses WHERE id = $1`, id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrStatusInUse
+		}
+		return fmt.Errorf("deleting status: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrStatusNotFound
+	}
+
+	return nil
+}
+
+// scanStatus reads a row selected with statusColumns into a domain.StatusDefinition
+func scanStatus(row pgx.Row) (*domain.StatusDefinition, error) {
+	var status domain.StatusDefinition
+	err := row.Scan(
+		&status.ID,
+		&status.ProjectID,
+		&status.Name,
+		&status.Category,
+		&status.Position,
+		&status.Color,
+		&status.CreatedAt,
+		&status.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// isUniqueViolation reports whether err is a Postgres unique_violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// isForeignKeyViolation reports whether err is a Postgres foreign_key_violation
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
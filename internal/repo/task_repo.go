@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -12,14 +15,60 @@ import (
 
 	"task-svc/internal/domain"
 	"task-svc/internal/platform/db"
+	"task-svc/pkg/filter"
 )
 
 // Common errors
 var (
 	ErrNotFound      = errors.New("task not found")
 	ErrVersionConflict = errors.New("version conflict")
+	// ErrOccurrenceExists is returned when the next occurrence of a recurring task was already created
+	ErrOccurrenceExists = errors.New("next occurrence already exists")
+	// ErrParentDeleted is returned when restoring a task whose parent is still in the trash
+	ErrParentDeleted = errors.New("parent task is deleted")
 )
 
+// taskColumns lists the task table columns read by scanTask
+const taskColumns = `id, project_id, number, title, description, status, priority, severity, due_date, parent_id, assignee_id, reporter_id, recurrence, recurs_from_id, created_at, updated_at, version, completed_at, archived_at, deleted_at`
+
+// taskLabelsExpr aggregates the label names of the task row aliased as %[1]s
+const taskLabelsExpr = `COALESCE((
+		SELECT array_agg(l.name ORDER BY l.name)
+		FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+		WHERE tl.task_id = %[1]s.id
+	), '{}')`
+
+// taskProjectKeyExpr looks up the project key of the task row aliased as %[1]s
+const taskProjectKeyExpr = `(SELECT p.key FROM projects p WHERE p.id = %[1]s.project_id)`
+
+// taskPriorityRankExpr numbers a task's priority from 1 (most urgent) upwards
+var taskPriorityRankExpr = func() string {
+	names := make([]string, len(domain.Priorities))
+	for i, p := range domain.Priorities {
+		names[i] = "'" + string(p) + "'"
+	}
+	return "array_position(ARRAY[" + strings.Join(names, ", ") + "]::text[], priority::text)"
+}()
+
+// taskSeverityRankExpr numbers a task's severity from 1 (most severe)
+// upwards, and is null for tasks without a severity
+var taskSeverityRankExpr = func() string {
+	names := make([]string, len(domain.Severities))
+	for i, s := range domain.Severities {
+		names[i] = "'" + string(s) + "'"
+	}
+	return "array_position(ARRAY[" + strings.Join(names, ", ") + "]::text[], severity::text)"
+}()
+
+// taskSortColumns maps the sortable fields of a task list to SQL expressions
+var taskSortColumns = map[string]string{
+	"created_at": "created_at",
+	"due_date":   "due_date",
+	"priority":   taskPriorityRankExpr,
+	"severity":   taskSeverityRankExpr,
+	"deleted_at": "deleted_at",
+}
+
 // TaskRepo handles database operations for tasks
 type TaskRepo struct {
 	db *db.Pool
@@ -30,54 +79,155 @@ func NewTaskRepo(db *db.Pool) *TaskRepo {
 	return &TaskRepo{db: db}
 }
 
-// Create inserts a new task into the database
+// Create inserts a new task into the database together with its labels
+// and, when it starts out assigned, the initial assignment. Tasks created in
+// a project are given the project's next sequential number and key. The
+// first version is recorded in the task's history and a task.created event
+// in the outbox.
 func (r *TaskRepo) Create(ctx context.Context, task *domain.Task) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertTask(ctx, tx, task); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing task insert: %w", err)
+	}
+
+	return nil
+}
+
+// insertTask implements Create inside tx
+func insertTask(ctx context.Context, tx pgx.Tx, task *domain.Task) error {
 	query := `
-		INSERT INTO tasks (id, title, description, status, due_date, created_at, updated_at, version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO tasks (id, project_id, number, title, description, status, priority, severity, due_date, parent_id, assignee_id, reporter_id, recurrence, recurs_from_id, created_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 	`
 
-	_, err := r.db.Exec(ctx, query,
+	var number *int
+	if task.ProjectID != nil {
+		n, projectKey, err := nextTaskNumber(ctx, tx, *task.ProjectID)
+		if err != nil {
+			return err
+		}
+		key := domain.FormatTaskKey(projectKey, n)
+		number, task.Key = &n, &key
+	}
+
+	_, err := tx.Exec(ctx, query,
 		task.ID,
+		task.ProjectID,
+		number,
 		task.Title,
 		task.Description,
 		task.Status,
+		task.Priority,
+		task.Severity,
 		task.DueDate,
+		task.ParentID,
+		task.AssigneeID,
+		task.ReporterID,
+		task.Recurrence,
+		task.RecursFromID,
 		task.CreatedAt,
 		task.UpdatedAt,
 		task.Version,
 	)
 
 	if err != nil {
+		if isUserForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		if task.RecursFromID != nil && isUniqueViolation(err) {
+			return ErrOccurrenceExists
+		}
 		return fmt.Errorf("inserting task: %w", err)
 	}
 
+	if err := attachLabels(ctx, tx, task.ID, task.Labels); err != nil {
+		return err
+	}
+	if err := recordAssignment(ctx, tx, task.ID, nil, task.AssigneeID); err != nil {
+		return err
+	}
+	if err := recordTaskVersion(ctx, tx, domain.HistoryCreated, task.ID, nil); err != nil {
+		return err
+	}
+	return recordTaskEvent(ctx, tx, domain.EventTaskCreated, task.ID, nil)
+}
+
+// createOccurrence creates, inside tx, the occurrence next returns for a task
+// that was just saved there with a change of status from previousStatus. It
+// is inserted under a savepoint, so that an occurrence that already exists or
+// a project archived meanwhile skips it without undoing the change.
+func createOccurrence(ctx context.Context, tx pgx.Tx, id uuid.UUID, previousStatus, status domain.Status, next func(context.Context, *domain.Task, domain.Status) (*domain.Task, error)) error {
+	if next == nil || status == previousStatus {
+		return nil
+	}
+
+	saved, err := scanTask(tx.QueryRow(ctx, `SELECT `+selectTaskColumns("tasks")+` FROM tasks WHERE id = $1`, id))
+	if err != nil {
+		return fmt.Errorf("selecting saved task: %w", err)
+	}
+	occurrence, err := next(ctx, saved, previousStatus)
+	if err != nil || occurrence == nil {
+		return err
+	}
+
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning savepoint: %w", err)
+	}
+	defer savepoint.Rollback(ctx)
+
+	if err := insertTask(ctx, savepoint, occurrence); err != nil {
+		if errors.Is(err, ErrOccurrenceExists) || errors.Is(err, ErrProjectArchived) {
+			return nil
+		}
+		return err
+	}
+
+	if err := savepoint.Commit(ctx); err != nil {
+		return fmt.Errorf("releasing savepoint: %w", err)
+	}
 	return nil
 }
 
-// Get retrieves a task by ID
+// Get retrieves a task by ID. Deleted tasks are reported as ErrNotFound.
 func (r *TaskRepo) Get(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
-	query := `
-		SELECT id, title, description, status, due_date, created_at, updated_at, version
-		FROM tasks
-		WHERE id = $1
-	`
+	return r.get(ctx, `id = $1 AND deleted_at IS NULL`, id)
+}
 
-	var task domain.Task
-	var description pgtype.Text
-	var dueDate pgtype.Timestamptz
+// GetIncludingDeleted retrieves a task by ID, even when it is in the trash
+func (r *TaskRepo) GetIncludingDeleted(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	return r.get(ctx, `id = $1`, id)
+}
 
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&task.ID,
-		&task.Title,
-		&description,
-		&task.Status,
-		&dueDate,
-		&task.CreatedAt,
-		&task.UpdatedAt,
-		&task.Version,
-	)
+// GetByKey retrieves a task by its project key and number, as in BILL-123.
+// Deleted tasks are reported as ErrNotFound.
+func (r *TaskRepo) GetByKey(ctx context.Context, projectKey string, number int) (*domain.Task, error) {
+	return r.get(ctx, taskKeyCondition+` AND deleted_at IS NULL`, projectKey, number)
+}
+
+// GetByKeyIncludingDeleted retrieves a task by its project key and number,
+// even when it is in the trash
+func (r *TaskRepo) GetByKeyIncludingDeleted(ctx context.Context, projectKey string, number int) (*domain.Task, error) {
+	return r.get(ctx, taskKeyCondition, projectKey, number)
+}
+
+// taskKeyCondition matches the task with the project key $1 and number $2
+const taskKeyCondition = `project_id = (SELECT id FROM projects WHERE key = $1) AND number = $2`
 
+// get retrieves the task matching condition
+func (r *TaskRepo) get(ctx context.Context, condition string, args ...any) (*domain.Task, error) {
+	query := `SELECT ` + selectTaskColumns("tasks") + ` FROM tasks WHERE ` + condition
+
+	task, err := scanTask(r.db.QueryRow(ctx, query, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -85,140 +235,129 @@ func (r *TaskRepo) Get(ctx context.Context, id uuid.UUID) (*domain.Task, error)
 		return nil, fmt.Errorf("selecting task: %w", err)
 	}
 
-	// Handle nullable fields
-	if description.Valid {
-		desc := description.String
-		task.Description = &desc
-	}
-
-	if dueDate.Valid {
-		due := dueDate.Time
-		task.DueDate = &due
-	}
-
-	return &task, nil
+	return task, nil
 }
 
-// List retrieves tasks with pagination and filtering
+// List retrieves a page of tasks, selected by number or by cursor. One task
+// more than the page holds is fetched to learn whether another page follows;
+// tasks before a cursor are fetched in reverse order and turned around.
 func (r *TaskRepo) List(ctx context.Context, filter domain.TaskFilter) (*domain.TaskList, error) {
-	// First get the total count for pagination metadata
-	countQuery := `
-		SELECT count(*) 
-		FROM tasks 
-		WHERE ($1::task_status IS NULL OR status = $1)
-	`
+	where := taskListWhere(filter)
+	list := &domain.TaskList{Items: []domain.Task{}, Meta: domain.PageMeta{PageSize: filter.Size}}
 
-	var total int
-	err := r.db.QueryRow(ctx, countQuery, filter.Status).Scan(&total)
-	if err != nil {
-		return nil, fmt.Errorf("counting tasks: %w", err)
+	if filter.CountTotal {
+		countQuery := `SELECT count(*) FROM tasks ` + where.clause()
+
+		var total int
+		if err := r.db.QueryRow(ctx, countQuery, where.args...).Scan(&total); err != nil {
+			return nil, fmt.Errorf("counting tasks: %w", err)
+		}
+		totalPages := int(math.Ceil(float64(total) / float64(filter.Size)))
+		list.Meta.TotalItems = &total
+		list.Meta.TotalPages = &totalPages
+
+		// If there are no items, return an empty list with metadata
+		if total == 0 {
+			if filter.After == nil && filter.Before == nil {
+				list.Meta.Page = filter.Page
+			}
+			return list, nil
+		}
+	}
+
+	order := taskOrder(filter.Sort)
+	page := ""
+	switch {
+	case filter.After != nil:
+		where.where(taskKeysetCondition(where, order, *filter.After))
+	case filter.Before != nil:
+		order = reverseOrder(order)
+		where.where(taskKeysetCondition(where, order, *filter.Before))
+	default:
+		list.Meta.Page = filter.Page
+		page = ` OFFSET ` + where.arg((filter.Page-1)*filter.Size)
 	}
 
-	// Calculate pagination metadata
-	totalPages := int(math.Ceil(float64(total) / float64(filter.Size)))
-	
-	// If there are no items, return an empty list with metadata
-	if total == 0 {
-		return &domain.TaskList{
-			Items: []domain.Task{},
-			Meta: domain.PageMeta{
-				Page:       filter.Page,
-				PageSize:   filter.Size,
-				TotalItems: total,
-				TotalPages: totalPages,
-			},
-		}, nil
-	}
-
-	// Otherwise fetch the requested page
 	query := `
-		SELECT id, title, description, status, due_date, created_at, updated_at, version
+		SELECT ` + selectTaskColumns("tasks") + `
 		FROM tasks
-		WHERE ($1::task_status IS NULL OR status = $1)
-		ORDER BY 
-			CASE WHEN $2 = 'created_at' THEN created_at END ASC,
-			CASE WHEN $2 = '-created_at' THEN created_at END DESC,
-			CASE WHEN $2 = 'due_date' THEN due_date END ASC,
-			CASE WHEN $2 = '-due_date' THEN due_date END DESC,
-			created_at DESC
-		LIMIT $3 OFFSET $4
-	`
+		` + where.clause() + `
+		ORDER BY ` + strings.Join(orderTermsSQL(order), ", ") + `
+		LIMIT ` + where.arg(filter.Size+1) + page
 
-	offset := (filter.Page - 1) * filter.Size
-	rows, err := r.db.Query(ctx, query, filter.Status, filter.Sort, filter.Size, offset)
+	tasks, err := r.queryTasks(ctx, query, where.args...)
 	if err != nil {
-		return nil, fmt.Errorf("selecting tasks: %w", err)
+		return nil, err
 	}
-	defer rows.Close()
 
-	tasks := []domain.Task{}
-	for rows.Next() {
-		var task domain.Task
-		var description pgtype.Text
-		var dueDate pgtype.Timestamptz
-
-		err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&description,
-			&task.Status,
-			&dueDate,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-			&task.Version,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("scanning task row: %w", err)
-		}
+	more := len(tasks) > filter.Size
+	if more {
+		tasks = tasks[:filter.Size]
+	}
+	if filter.Before != nil {
+		slices.Reverse(tasks)
+	}
+	list.Items = tasks
+	if len(tasks) == 0 {
+		return list, nil
+	}
 
-		// Handle nullable fields
-		if description.Valid {
-			desc := description.String
-			task.Description = &desc
+	first := domain.NewTaskCursor(tasks[0], filter.Sort)
+	last := domain.NewTaskCursor(tasks[len(tasks)-1], filter.Sort)
+	switch {
+	case filter.After != nil:
+		list.Prev = &first
+		if more {
+			list.Next = &last
 		}
-
-		if dueDate.Valid {
-			due := dueDate.Time
-			task.DueDate = &due
+	case filter.Before != nil:
+		list.Next = &last
+		if more {
+			list.Prev = &first
+		}
+	default:
+		if more {
+			list.Next = &last
+		}
+		if filter.Page > 1 {
+			list.Prev = &first
 		}
-
-		tasks = append(tasks, task)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating task rows: %w", err)
-	}
+	return list, nil
+}
 
-	return &domain.TaskList{
-		Items: tasks,
-		Meta: domain.PageMeta{
-			Page:       filter.Page,
-			PageSize:   filter.Size,
-			TotalItems: total,
-			TotalPages: totalPages,
-		},
-	}, nil
+// Update updates a task with optimistic locking, recording a change of assignee,
+// the new version in the task's history and the task's events in the outbox.
+// When the status changes, next is given the task as saved and the status it
+// left, and the occurrence it returns, if any, is created in the same
+// transaction; next may be nil.
+func (r *TaskRepo) Update(ctx context.Context, task *domain.Task, next func(context.Context, *domain.Task, domain.Status) (*domain.Task, error)) error {
+	return r.update(ctx, task, nil, next)
 }
 
-// Update updates a task with optimistic locking
-func (r *TaskRepo) Update(ctx context.Context, task *domain.Task) error {
-	query := `
-		UPDATE tasks
-		SET title = $2, description = $3, status = $4, due_date = $5
-		WHERE id = $1 AND version = $6
-		RETURNING version
-	`
+// Revert updates a task with optimistic locking like Update, and also sets its
+// labels to task.Labels, skipping labels deleted since. The new version is
+// recorded in the task's history as a revert to restoredVersion.
+func (r *TaskRepo) Revert(ctx context.Context, task *domain.Task, restoredVersion int, next func(context.Context, *domain.Task, domain.Status) (*domain.Task, error)) error {
+	return r.update(ctx, task, &restoredVersion, next)
+}
 
-	var newVersion int
-	err := r.db.QueryRow(ctx, query,
-		task.ID,
-		task.Title,
-		task.Description,
-		task.Status,
-		task.DueDate,
-		task.Version,
-	).Scan(&newVersion)
+// update implements Update and, when restoredVersion is set, Revert
+func (r *TaskRepo) update(ctx context.Context, task *domain.Task, restoredVersion *int, next func(context.Context, *domain.Task, domain.Status) (*domain.Task, error)) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
+	// Lock the row at the expected version and capture the assignee and status being replaced
+	var previousAssignee pgtype.UUID
+	var previousStatus domain.Status
+	err = tx.QueryRow(ctx,
+		`SELECT assignee_id, status FROM tasks WHERE id = $1 AND version = $2 AND deleted_at IS NULL FOR UPDATE`,
+		task.ID, task.Version,
+	).Scan(&previousAssignee, &previousStatus)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			// Check if the task exists at all
@@ -232,31 +371,365 @@ func (r *TaskRepo) Update(ctx context.Context, task *domain.Task) error {
 			// Task exists but version doesn't match
 			return ErrVersionConflict
 		}
+		return fmt.Errorf("locking task: %w", err)
+	}
+
+	query := `
+		UPDATE tasks
+		SET title = $2, description = $3, status = $4, due_date = $5, parent_id = $6, assignee_id = $7, priority = $8, recurrence = $9, severity = $10
+		WHERE id = $1
+		RETURNING version
+	`
+
+	var newVersion int
+	err = tx.QueryRow(ctx, query,
+		task.ID,
+		task.Title,
+		task.Description,
+		task.Status,
+		task.DueDate,
+		task.ParentID,
+		task.AssigneeID,
+		task.Priority,
+		task.Recurrence,
+		task.Severity,
+	).Scan(&newVersion)
+	if err != nil {
+		if isUserForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
 		return fmt.Errorf("updating task: %w", err)
 	}
 
+	if err := recordAssignment(ctx, tx, task.ID, nullableUUID(previousAssignee), task.AssigneeID); err != nil {
+		return err
+	}
+	if restoredVersion != nil {
+		if err := replaceLabels(ctx, tx, task.ID, task.Labels); err != nil {
+			return err
+		}
+		if err := recordTaskVersion(ctx, tx, domain.HistoryReverted, task.ID, restoredVersion); err != nil {
+			return err
+		}
+	} else if err := recordTaskVersion(ctx, tx, domain.HistoryUpdated, task.ID, nil); err != nil {
+		return err
+	}
+	if err := recordTaskChange(ctx, tx, task.ID, previousStatus, task.Status); err != nil {
+		return err
+	}
+	if err := createOccurrence(ctx, tx, task.ID, previousStatus, task.Status, next); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing task update: %w", err)
+	}
+
 	task.Version = newVersion
 	return nil
 }
 
-// Delete removes a task by ID
-func (r *TaskRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM tasks WHERE id = $1`
-
-	result, err := r.db.Exec(ctx, query, id)
+// Delete moves a task to the trash. Subtasks are either moved to the trash
+// with it or moved up to the deleted task's parent. Every deleted task gets a
+// new version in its history and a task.deleted event; every moved subtask a
+// new version and a task.updated event. Deleted tasks keep their place in the
+// hierarchy, so that Restore can bring them back together.
+func (r *TaskRepo) Delete(ctx context.Context, id uuid.UUID, children domain.ChildPolicy) error {
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("deleting task: %w", err)
+		return fmt.Errorf("beginning transaction: %w", err)
 	}
+	defer tx.Rollback(ctx)
 
-	if result.RowsAffected() == 0 {
+	// Lock the tasks about to be deleted
+	lockQuery := `SELECT id FROM tasks WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	if children == domain.ChildPolicyCascade {
+		lockQuery = `
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM tasks WHERE id = $1 AND deleted_at IS NULL
+				UNION
+				SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+			)
+			SELECT id FROM tasks WHERE id IN (SELECT id FROM subtree) ORDER BY created_at FOR UPDATE
+		`
+	}
+	removed, err := queryIDs(ctx, tx, lockQuery, id)
+	if err != nil {
+		return fmt.Errorf("locking tasks: %w", err)
+	}
+	if len(removed) == 0 {
 		return ErrNotFound
 	}
 
+	if children == domain.ChildPolicyReparent {
+		reparentQuery := `
+			UPDATE tasks
+			SET parent_id = (SELECT parent_id FROM tasks WHERE id = $1)
+			WHERE parent_id = $1 AND deleted_at IS NULL
+			RETURNING id
+		`
+		moved, err := queryIDs(ctx, tx, reparentQuery, id)
+		if err != nil {
+			return fmt.Errorf("reparenting subtasks: %w", err)
+		}
+		for _, child := range moved {
+			if err := recordTaskVersion(ctx, tx, domain.HistoryUpdated, child, nil); err != nil {
+				return err
+			}
+			if err := recordTaskEvent(ctx, tx, domain.EventTaskUpdated, child, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Every task deleted together shares the same tombstone, the transaction's timestamp
+	if _, err := tx.Exec(ctx, `UPDATE tasks SET deleted_at = now() WHERE id = ANY($1)`, removed); err != nil {
+		return fmt.Errorf("deleting tasks: %w", err)
+	}
+
+	for _, taskID := range removed {
+		if err := recordTaskVersion(ctx, tx, domain.HistoryDeleted, taskID, nil); err != nil {
+			return err
+		}
+		if err := recordTaskEvent(ctx, tx, domain.EventTaskDeleted, taskID, nil); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing task delete: %w", err)
+	}
+
 	return nil
 }
 
-// Patch applies a partial update to a task
-func (r *TaskRepo) Patch(ctx context.Context, id uuid.UUID, patch *domain.PatchTaskRequest) (*domain.Task, error) {
+// Restore brings a task back from the trash together with the subtasks that
+// were deleted with it. Every restored task gets a new version in its history
+// and a task.restored event. A task whose parent is still in the trash is
+// reported as ErrParentDeleted.
+func (r *TaskRepo) Restore(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	lockQuery := `
+		SELECT t.deleted_at, COALESCE(p.deleted_at IS NOT NULL, false)
+		FROM tasks t
+		LEFT JOIN tasks p ON p.id = t.parent_id
+		WHERE t.id = $1 AND t.deleted_at IS NOT NULL
+		FOR UPDATE OF t
+	`
+	var deletedAt time.Time
+	var parentDeleted bool
+	if err := tx.QueryRow(ctx, lockQuery, id).Scan(&deletedAt, &parentDeleted); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("locking task: %w", err)
+	}
+	if parentDeleted {
+		return ErrParentDeleted
+	}
+
+	// Subtasks deleted with the task share its tombstone; those deleted on
+	// their own before it stay in the trash
+	restoreQuery := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM tasks WHERE id = $1
+			UNION
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at = $2
+		)
+		UPDATE tasks SET deleted_at = NULL
+		WHERE id IN (SELECT id FROM subtree)
+		RETURNING id
+	`
+	restored, err := queryIDs(ctx, tx, restoreQuery, id, deletedAt)
+	if err != nil {
+		return fmt.Errorf("restoring tasks: %w", err)
+	}
+
+	for _, taskID := range restored {
+		if err := recordTaskVersion(ctx, tx, domain.HistoryRestored, taskID, nil); err != nil {
+			return err
+		}
+		if err := recordTaskEvent(ctx, tx, domain.EventTaskRestored, taskID, nil); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing task restore: %w", err)
+	}
+
+	return nil
+}
+
+// Purge permanently removes the tasks deleted before the given time and their
+// subtasks at any depth, together with everything attached to them, and
+// returns how many were removed. The storage keys of their attachments are
+// handed to removeContents before any row is deleted; when it fails nothing is
+// purged, so the purge can be retried.
+func (r *TaskRepo) Purge(ctx context.Context, before time.Time, removeContents func(context.Context, []string) error) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the expired tasks and their subtrees, which the parent's foreign
+	// key would delete along with them, so that none is restored or changed
+	// while its contents go
+	lockQuery := `
+		WITH RECURSIVE doomed AS (
+			SELECT id FROM tasks WHERE deleted_at < $1
+			UNION
+			SELECT t.id FROM tasks t JOIN doomed d ON t.parent_id = d.id
+		)
+		SELECT id FROM tasks WHERE id IN (SELECT id FROM doomed) FOR UPDATE
+	`
+	ids, err := queryIDs(ctx, tx, lockQuery, before)
+	if err != nil {
+		return 0, fmt.Errorf("selecting expired tasks: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	keys, err := deleteTaskAttachments(ctx, tx, ids)
+	if err != nil {
+		return 0, err
+	}
+	if len(keys) > 0 {
+		if err := removeContents(ctx, keys); err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM tasks WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return 0, fmt.Errorf("purging deleted tasks: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("committing purge: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// Archive archives a task, leaving it out of task lists by default. Archiving
+// an archived task changes nothing. The task gets a new version in its
+// history and a task.archived event.
+func (r *TaskRepo) Archive(ctx context.Context, id uuid.UUID) error {
+	return r.setArchived(ctx, id, true)
+}
+
+// Unarchive brings an archived task back into task lists. Unarchiving a task
+// that is not archived changes nothing. The task gets a new version in its
+// history and a task.unarchived event.
+func (r *TaskRepo) Unarchive(ctx context.Context, id uuid.UUID) error {
+	return r.setArchived(ctx, id, false)
+}
+
+// setArchived archives or unarchives a task outside the trash
+func (r *TaskRepo) setArchived(ctx context.Context, id uuid.UUID, archived bool) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var archivedAt pgtype.Timestamptz
+	lockQuery := `SELECT archived_at FROM tasks WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	if err := tx.QueryRow(ctx, lockQuery, id).Scan(&archivedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("locking task: %w", err)
+	}
+	if archivedAt.Valid == archived {
+		return nil
+	}
+
+	action, eventType := domain.HistoryUnarchived, domain.EventTaskUnarchived
+	if archived {
+		action, eventType = domain.HistoryArchived, domain.EventTaskArchived
+	}
+
+	updateQuery := `UPDATE tasks SET archived_at = CASE WHEN $2 THEN now() END WHERE id = $1`
+	if _, err := tx.Exec(ctx, updateQuery, id, archived); err != nil {
+		return fmt.Errorf("archiving task: %w", err)
+	}
+	if err := recordTaskVersion(ctx, tx, action, id, nil); err != nil {
+		return err
+	}
+	if err := recordTaskEvent(ctx, tx, eventType, id, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing task archive: %w", err)
+	}
+
+	return nil
+}
+
+// ArchiveCompleted archives up to limit tasks in a done-category status that
+// were completed before the given time, optionally within one project, and
+// returns how many were archived. The oldest are archived first; tasks locked
+// by other transactions are skipped until a later call. Every archived task
+// gets a new version in its history and a task.archived event.
+func (r *TaskRepo) ArchiveCompleted(ctx context.Context, before time.Time, projectID *uuid.UUID, limit int) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	archiveQuery := `
+		WITH batch AS (
+			SELECT t.id
+			FROM tasks t
+			JOIN statuses s ON s.name = t.status AND s.category = 'done'
+			WHERE t.completed_at < $1
+				AND t.archived_at IS NULL
+				AND t.deleted_at IS NULL
+				AND ($2::uuid IS NULL OR t.project_id = $2)
+			ORDER BY t.completed_at, t.id
+			LIMIT $3
+			FOR UPDATE OF t SKIP LOCKED
+		)
+		UPDATE tasks SET archived_at = now()
+		WHERE id IN (SELECT id FROM batch)
+		RETURNING id
+	`
+	archived, err := queryIDs(ctx, tx, archiveQuery, before, projectID, limit)
+	if err != nil {
+		return 0, fmt.Errorf("archiving completed tasks: %w", err)
+	}
+
+	for _, taskID := range archived {
+		if err := recordTaskVersion(ctx, tx, domain.HistoryArchived, taskID, nil); err != nil {
+			return 0, err
+		}
+		if err := recordTaskEvent(ctx, tx, domain.EventTaskArchived, taskID, nil); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("committing task archive: %w", err)
+	}
+
+	return len(archived), nil
+}
+
+// Patch applies a partial update to a task, recording the new version in the
+// task's history and its events in the outbox. A change of status creates the
+// occurrence next returns as in Update.
+func (r *TaskRepo) Patch(ctx context.Context, id uuid.UUID, patch *domain.PatchTaskRequest, next func(context.Context, *domain.Task, domain.Status) (*domain.Task, error)) (*domain.Task, error) {
 	// First get the current task
 	task, err := r.Get(ctx, id)
 	if err != nil {
@@ -269,6 +742,7 @@ func (r *TaskRepo) Patch(ctx context.Context, id uuid.UUID, patch *domain.PatchT
 	}
 
 	// Apply patch changes
+	previousStatus := task.Status
 	if patch.Title != nil {
 		task.Title = *patch.Title
 	}
@@ -278,40 +752,198 @@ func (r *TaskRepo) Patch(ctx context.Context, id uuid.UUID, patch *domain.PatchT
 	if patch.Status != nil {
 		task.Status = *patch.Status
 	}
+	if patch.Priority != nil {
+		task.Priority = *patch.Priority
+	}
+	if patch.Severity != nil {
+		task.Severity = patch.Severity
+	}
+	if patch.ClearSeverity {
+		task.Severity = nil
+	}
 	if patch.DueDate != nil {
 		task.DueDate = patch.DueDate
 	}
+	if patch.ParentID != nil {
+		task.ParentID = patch.ParentID
+	}
+	if patch.ClearParent {
+		task.ParentID = nil
+	}
+	previousAssignee := task.AssigneeID
+	if patch.AssigneeID != nil {
+		task.AssigneeID = patch.AssigneeID
+	}
+	if patch.Unassign {
+		task.AssigneeID = nil
+	}
+	if patch.Recurrence != nil {
+		task.Recurrence = patch.Recurrence
+		if *patch.Recurrence == "" {
+			task.Recurrence = nil
+		}
+	}
 
 	// Update in database
 	query := `
 		UPDATE tasks
-		SET title = $2, description = $3, status = $4, due_date = $5
-		WHERE id = $1 AND version = $6
+		SET title = $2, description = $3, status = $4, due_date = $5, parent_id = $6, assignee_id = $7, priority = $9, recurrence = $10, severity = $11
+		WHERE id = $1 AND version = $8 AND deleted_at IS NULL
 		RETURNING version, updated_at
 	`
 
-	err = r.db.QueryRow(ctx, query,
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, query,
 		task.ID,
 		task.Title,
 		task.Description,
 		task.Status,
 		task.DueDate,
+		task.ParentID,
+		task.AssigneeID,
 		task.Version,
+		task.Priority,
+		task.Recurrence,
+		task.Severity,
 	).Scan(&task.Version, &task.UpdatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrVersionConflict
 		}
+		if isUserForeignKeyViolation(err) {
+			return nil, ErrUserNotFound
+		}
 		return nil, fmt.Errorf("patching task: %w", err)
 	}
 
+	if err := recordAssignment(ctx, tx, task.ID, previousAssignee, task.AssigneeID); err != nil {
+		return nil, err
+	}
+
+	if err := attachLabels(ctx, tx, task.ID, patch.AddLabels); err != nil {
+		return nil, err
+	}
+	if len(patch.RemoveLabels) > 0 {
+		removeQuery := `
+			DELETE FROM task_labels
+			WHERE task_id = $1 AND label_id IN (SELECT id FROM labels WHERE name = ANY($2))
+		`
+		if _, err := tx.Exec(ctx, removeQuery, task.ID, patch.RemoveLabels); err != nil {
+			return nil, fmt.Errorf("detaching labels: %w", err)
+		}
+	}
+	if len(patch.AddLabels) > 0 || len(patch.RemoveLabels) > 0 {
+		labelsQuery := `SELECT ` + fmt.Sprintf(taskLabelsExpr, "tasks") + ` FROM tasks WHERE id = $1`
+		if err := tx.QueryRow(ctx, labelsQuery, task.ID).Scan(&task.Labels); err != nil {
+			return nil, fmt.Errorf("selecting task labels: %w", err)
+		}
+	}
+	if err := recordTaskVersion(ctx, tx, domain.HistoryUpdated, task.ID, nil); err != nil {
+		return nil, err
+	}
+	if err := recordTaskChange(ctx, tx, task.ID, previousStatus, task.Status); err != nil {
+		return nil, err
+	}
+	if err := createOccurrence(ctx, tx, task.ID, previousStatus, task.Status, next); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing task patch: %w", err)
+	}
+
 	return task, nil
 }
 
-// exists checks if a task with the given ID exists
+// Subtree returns the task with the given ID followed by all of its descendants
+// that are not in the trash, oldest first
+func (r *TaskRepo) Subtree(ctx context.Context, id uuid.UUID) ([]domain.Task, error) {
+	// UNION (rather than UNION ALL) guarantees termination even if a cycle slipped in
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND deleted_at IS NULL
+			UNION
+			SELECT ` + prefixedTaskColumns("t") + `
+			FROM tasks t
+			JOIN subtree s ON t.parent_id = s.id
+			WHERE t.deleted_at IS NULL
+		)
+		SELECT ` + selectTaskColumns("subtree") + ` FROM subtree ORDER BY (id = $1) DESC, created_at
+	`
+
+	tasks, err := r.queryTasks(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tasks) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return tasks, nil
+}
+
+// IsDescendant reports whether candidate is ancestor itself or lies anywhere below it
+func (r *TaskRepo) IsDescendant(ctx context.Context, ancestor, candidate uuid.UUID) (bool, error) {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM tasks WHERE id = $1
+			UNION
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id
+		)
+		SELECT EXISTS(SELECT 1 FROM subtree WHERE id = $2)
+	`
+
+	var found bool
+	if err := r.db.QueryRow(ctx, query, ancestor, candidate).Scan(&found); err != nil {
+		return false, fmt.Errorf("checking task ancestry: %w", err)
+	}
+
+	return found, nil
+}
+
+// ChildCategoryCounts returns the number of direct subtasks outside the trash in each status category
+func (r *TaskRepo) ChildCategoryCounts(ctx context.Context, parentID uuid.UUID) (map[domain.StatusCategory]int, error) {
+	query := `
+		SELECT s.category, count(*)
+		FROM tasks t
+		JOIN statuses s ON s.name = t.status
+		WHERE t.parent_id = $1 AND t.deleted_at IS NULL
+		GROUP BY s.category
+	`
+
+	rows, err := r.db.Query(ctx, query, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("counting subtasks: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.StatusCategory]int)
+	for rows.Next() {
+		var category domain.StatusCategory
+		var n int
+		if err := rows.Scan(&category, &n); err != nil {
+			return nil, fmt.Errorf("scanning subtask count: %w", err)
+		}
+		counts[category] = n
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating subtask counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// exists checks if a task with the given ID exists outside the trash
 func (r *TaskRepo) exists(ctx context.Context, id uuid.UUID) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1)`
+	query := `SELECT EXISTS(SELECT 1 FROM tasks WHERE id = $1 AND deleted_at IS NULL)`
 	
 	var exists bool
 	err := r.db.QueryRow(ctx, query, id).Scan(&exists)
@@ -321,3 +953,457 @@ func (r *TaskRepo) exists(ctx context.Context, id uuid.UUID) (bool, error) {
 	
 	return exists, nil
 }
+
+// queryTasks runs a query selecting taskColumns and collects the resulting tasks
+func (r *TaskRepo) queryTasks(ctx context.Context, query string, args ...any) ([]domain.Task, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("selecting tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []domain.Task{}
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning task row: %w", err)
+		}
+		tasks = append(tasks, *task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating task rows: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// selectTaskColumns returns the select list read by scanTask for tasks aliased as alias
+func selectTaskColumns(alias string) string {
+	return prefixedTaskColumns(alias) + ", " + fmt.Sprintf(taskLabelsExpr, alias) + ", " + fmt.Sprintf(taskProjectKeyExpr, alias)
+}
+
+// orderTerm is one term of an ORDER BY clause
+type orderTerm struct {
+	expr string
+	desc bool
+}
+
+// taskOrder lists the ORDER BY terms of a task list, one per sort field and
+// in the same order, then newest first to break ties and the ID to make the
+// order total
+func taskOrder(sort []domain.SortField) []orderTerm {
+	terms := make([]orderTerm, 0, len(sort)+2)
+	for _, s := range sort {
+		column, ok := taskSortColumns[s.Field]
+		if !ok {
+			continue
+		}
+		terms = append(terms, orderTerm{expr: column, desc: s.Desc})
+	}
+	return append(terms, orderTerm{expr: "created_at", desc: true}, orderTerm{expr: "id"})
+}
+
+// taskOrderTerms lists the ORDER BY terms of a task list as SQL
+func taskOrderTerms(sort []domain.SortField) []string {
+	return orderTermsSQL(taskOrder(sort))
+}
+
+// orderTermsSQL formats ORDER BY terms. Nulls take PostgreSQL's default
+// place, last in ascending and first in descending order, so that reversing
+// every direction reverses the whole order.
+func orderTermsSQL(terms []orderTerm) []string {
+	sql := make([]string, len(terms))
+	for i, t := range terms {
+		if t.desc {
+			sql[i] = t.expr + " DESC"
+		} else {
+			sql[i] = t.expr + " ASC"
+		}
+	}
+	return sql
+}
+
+// reverseOrder reverses the direction of every ORDER BY term
+func reverseOrder(terms []orderTerm) []orderTerm {
+	reversed := make([]orderTerm, len(terms))
+	for i, t := range terms {
+		reversed[i] = orderTerm{expr: t.expr, desc: !t.desc}
+	}
+	return reversed
+}
+
+// taskKeysetCondition builds the condition selecting the tasks that come
+// after cursor in the order of terms, as built by taskOrder from the sort the
+// cursor was taken from. A task comes after the cursor when it equals the
+// cursor's keys up to some term and comes after it on that term.
+func taskKeysetCondition(b *whereBuilder, terms []orderTerm, cursor domain.TaskCursor) string {
+	keys := append(append([]any{}, cursor.Keys...), cursor.CreatedAt, cursor.ID)
+
+	var alternatives []string
+	var equal []string
+	for i, t := range terms {
+		key := keys[i]
+
+		// Nulls come last in ascending and first in descending order
+		var after string
+		switch {
+		case key == nil && t.desc:
+			after = t.expr + " IS NOT NULL"
+		case key == nil:
+			after = ""
+		case t.desc:
+			after = t.expr + " < " + b.arg(key)
+		default:
+			after = "(" + t.expr + " > " + b.arg(key) + " OR " + t.expr + " IS NULL)"
+		}
+		if after != "" {
+			alternatives = append(alternatives, "("+strings.Join(append(slices.Clone(equal), after), " AND ")+")")
+		}
+
+		if key == nil {
+			equal = append(equal, t.expr+" IS NULL")
+		} else {
+			equal = append(equal, t.expr+" = "+b.arg(key))
+		}
+	}
+
+	if len(alternatives) == 0 {
+		return "false"
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")"
+}
+
+// taskListWhere builds the WHERE clause shared by the count and page queries of List
+func taskListWhere(filter domain.TaskFilter) *whereBuilder {
+	b := &whereBuilder{}
+	switch {
+	case filter.Deleted:
+		b.where("deleted_at IS NOT NULL")
+	case !filter.IncludeDeleted:
+		b.where("deleted_at IS NULL")
+	}
+	switch {
+	case filter.Archived:
+		b.where("archived_at IS NOT NULL")
+	case !filter.IncludeArchived && !filter.Deleted:
+		b.where("archived_at IS NULL")
+	}
+	if filter.Status != nil {
+		b.where("status = " + b.arg(*filter.Status))
+	}
+	if len(filter.Priorities) > 0 {
+		priorities := make([]string, len(filter.Priorities))
+		for i, p := range filter.Priorities {
+			priorities[i] = string(p)
+		}
+		b.where("priority = ANY(" + b.arg(priorities) + ")")
+	}
+	if len(filter.Severities) > 0 {
+		severities := make([]string, len(filter.Severities))
+		for i, s := range filter.Severities {
+			severities[i] = string(s)
+		}
+		b.where("severity = ANY(" + b.arg(severities) + ")")
+	}
+	if filter.ParentID != nil {
+		b.where("parent_id = " + b.arg(*filter.ParentID))
+	}
+	if filter.ProjectID != nil {
+		b.where("project_id = " + b.arg(*filter.ProjectID))
+	}
+	if filter.AssigneeID != nil {
+		b.where("assignee_id = " + b.arg(*filter.AssigneeID))
+	}
+	if filter.Unassigned {
+		b.where("assignee_id IS NULL")
+	}
+
+	labelMatch := `SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+		WHERE tl.task_id = tasks.id AND l.name = ANY(%s)`
+	if len(filter.LabelsAny) > 0 {
+		b.where("EXISTS (" + fmt.Sprintf(labelMatch, b.arg(filter.LabelsAny)) + ")")
+	}
+	if len(filter.LabelsAll) > 0 {
+		names := b.arg(filter.LabelsAll)
+		b.where(`(SELECT count(DISTINCT l.name) FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+			WHERE tl.task_id = tasks.id AND l.name = ANY(` + names + `)) = cardinality(` + names + `::text[])`)
+	}
+	if len(filter.LabelsNone) > 0 {
+		b.where("NOT EXISTS (" + fmt.Sprintf(labelMatch, b.arg(filter.LabelsNone)) + ")")
+	}
+	if filter.Search != nil {
+		b.where("search_vector @@ to_tsquery('english', " + b.arg(searchTSQuery(*filter.Search)) + ")")
+	}
+	if filter.Where != nil {
+		b.where(taskFilterCondition(b, filter.Where))
+	}
+
+	return b
+}
+
+// taskFilterColumns maps the fields of domain.TaskFilterFields other than
+// label to their columns
+var taskFilterColumns = map[string]string{
+	"status":       "status",
+	"priority":     "priority",
+	"severity":     "severity",
+	"title":        "title",
+	"description":  "description",
+	"due_date":     "due_date",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+	"completed_at": "completed_at",
+	"project_id":   "project_id",
+	"parent_id":    "parent_id",
+	"assignee_id":  "assignee_id",
+	"reporter_id":  "reporter_id",
+}
+
+// taskFilterCondition compiles a filter expression into a condition on the
+// tasks table, registering its values as arguments of b. A comparison with a
+// NULL column counts as false, also under not, so != and not in match the
+// tasks where the field is absent.
+func taskFilterCondition(b *whereBuilder, node filter.Node) string {
+	switch n := node.(type) {
+	case filter.And:
+		return "(" + taskFilterCondition(b, n.Left) + " AND " + taskFilterCondition(b, n.Right) + ")"
+	case filter.Or:
+		return "(" + taskFilterCondition(b, n.Left) + " OR " + taskFilterCondition(b, n.Right) + ")"
+	case filter.Not:
+		return "NOT COALESCE(" + taskFilterCondition(b, n.X) + ", false)"
+	case filter.Condition:
+		if n.Field == "label" {
+			return taskLabelCondition(b, n)
+		}
+		column := taskFilterColumns[n.Field]
+		switch n.Op {
+		case filter.OpIsNull:
+			return "(" + column + " IS NULL)"
+		case filter.OpIsNotNull:
+			return "(" + column + " IS NOT NULL)"
+		case filter.OpNe:
+			return "(" + column + " IS DISTINCT FROM " + b.arg(n.Values[0]) + ")"
+		case filter.OpMatch:
+			return "(" + column + " ILIKE " + b.arg(containsPattern(n.Values[0].(string))) + ")"
+		case filter.OpNotMatch:
+			return "(" + column + " IS NULL OR " + column + " NOT ILIKE " + b.arg(containsPattern(n.Values[0].(string))) + ")"
+		case filter.OpIn:
+			return "(" + column + " = ANY(" + b.arg(filterArray(n.Values)) + "))"
+		case filter.OpNotIn:
+			return "(" + column + " IS NULL OR " + column + " <> ALL(" + b.arg(filterArray(n.Values)) + "))"
+		default:
+			return "(" + column + " " + string(n.Op) + " " + b.arg(n.Values[0]) + ")"
+		}
+	default:
+		panic(fmt.Sprintf("unknown filter node %T", node))
+	}
+}
+
+// taskLabelCondition compiles a condition on the labels of a task
+func taskLabelCondition(b *whereBuilder, n filter.Condition) string {
+	hasLabel := `EXISTS (SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+		WHERE tl.task_id = tasks.id AND l.name = ANY(%s))`
+	switch n.Op {
+	case filter.OpIsNull:
+		return "NOT EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = tasks.id)"
+	case filter.OpIsNotNull:
+		return "EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = tasks.id)"
+	case filter.OpEq, filter.OpIn:
+		return fmt.Sprintf(hasLabel, b.arg(filterArray(n.Values)))
+	default:
+		return "NOT " + fmt.Sprintf(hasLabel, b.arg(filterArray(n.Values)))
+	}
+}
+
+// filterArray converts the values of a condition into a typed slice, so that
+// it is sent as an array of the field's type
+func filterArray(values []any) any {
+	switch values[0].(type) {
+	case uuid.UUID:
+		ids := make([]uuid.UUID, len(values))
+		for i, v := range values {
+			ids[i] = v.(uuid.UUID)
+		}
+		return ids
+	default:
+		names := make([]string, len(values))
+		for i, v := range values {
+			names[i] = v.(string)
+		}
+		return names
+	}
+}
+
+// containsPattern builds an ILIKE pattern matching text anywhere, escaping
+// the pattern's wildcards
+func containsPattern(text string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + escaper.Replace(text) + "%"
+}
+
+// attachLabels links the named labels to a task inside tx. Names that do not
+// exist are reported as ErrLabelNotFound; names already attached are ignored.
+func attachLabels(ctx context.Context, tx pgx.Tx, taskID uuid.UUID, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO task_labels (task_id, label_id)
+		SELECT $1, id FROM labels WHERE name = ANY($2)
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.Exec(ctx, query, taskID, names); err != nil {
+		return fmt.Errorf("attaching labels: %w", err)
+	}
+
+	var found int
+	if err := tx.QueryRow(ctx, `SELECT count(*) FROM labels WHERE name = ANY($1)`, names).Scan(&found); err != nil {
+		return fmt.Errorf("checking labels: %w", err)
+	}
+	if found < len(uniqueStrings(names)) {
+		return ErrLabelNotFound
+	}
+
+	return nil
+}
+
+// replaceLabels sets the labels of a task inside tx to the named labels that
+// still exist
+func replaceLabels(ctx context.Context, tx pgx.Tx, taskID uuid.UUID, names []string) error {
+	detachQuery := `
+		DELETE FROM task_labels
+		WHERE task_id = $1 AND label_id NOT IN (SELECT id FROM labels WHERE name = ANY($2))
+	`
+	if _, err := tx.Exec(ctx, detachQuery, taskID, names); err != nil {
+		return fmt.Errorf("detaching labels: %w", err)
+	}
+
+	attachQuery := `
+		INSERT INTO task_labels (task_id, label_id)
+		SELECT $1, id FROM labels WHERE name = ANY($2)
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.Exec(ctx, attachQuery, taskID, names); err != nil {
+		return fmt.Errorf("attaching labels: %w", err)
+	}
+
+	return nil
+}
+
+// uniqueStrings returns the distinct values of list in their original order
+func uniqueStrings(list []string) []string {
+	seen := make(map[string]bool, len(list))
+	out := make([]string, 0, len(list))
+	for _, v := range list {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// queryIDs runs a query selecting a single ID column inside tx and collects the IDs
+func queryIDs(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]uuid.UUID, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+}
+
+// prefixedTaskColumns qualifies taskColumns with a table alias for use in joins
+func prefixedTaskColumns(alias string) string {
+	return qualifyColumns(alias, taskColumns)
+}
+
+// scanTask reads a row selected with selectTaskColumns into a domain.Task
+func scanTask(row pgx.Row) (*domain.Task, error) {
+	var task domain.Task
+	var description pgtype.Text
+	var dueDate, completedAt, archivedAt, deletedAt pgtype.Timestamptz
+	var parentID, assigneeID, reporterID, projectID, recursFromID pgtype.UUID
+	var recurrence, severity pgtype.Text
+	var number pgtype.Int4
+	var projectKey pgtype.Text
+
+	err := row.Scan(
+		&task.ID,
+		&projectID,
+		&number,
+		&task.Title,
+		&description,
+		&task.Status,
+		&task.Priority,
+		&severity,
+		&dueDate,
+		&parentID,
+		&assigneeID,
+		&reporterID,
+		&recurrence,
+		&recursFromID,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+		&task.Version,
+		&completedAt,
+		&archivedAt,
+		&deletedAt,
+		&task.Labels,
+		&projectKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle nullable fields
+	if description.Valid {
+		desc := description.String
+		task.Description = &desc
+	}
+
+	if dueDate.Valid {
+		due := dueDate.Time
+		task.DueDate = &due
+	}
+
+	if completedAt.Valid {
+		completed := completedAt.Time
+		task.CompletedAt = &completed
+	}
+
+	if archivedAt.Valid {
+		archived := archivedAt.Time
+		task.ArchivedAt = &archived
+	}
+
+	if deletedAt.Valid {
+		deleted := deletedAt.Time
+		task.DeletedAt = &deleted
+	}
+
+	task.ParentID = nullableUUID(parentID)
+	task.AssigneeID = nullableUUID(assigneeID)
+	task.ReporterID = nullableUUID(reporterID)
+	task.ProjectID = nullableUUID(projectID)
+	task.RecursFromID = nullableUUID(recursFromID)
+
+	if severity.Valid {
+		s := domain.Severity(severity.String)
+		task.Severity = &s
+	}
+
+	if recurrence.Valid {
+		rule := recurrence.String
+		task.Recurrence = &rule
+	}
+
+	if projectKey.Valid && number.Valid {
+		key := domain.FormatTaskKey(projectKey.String, int(number.Int32))
+		task.Key = &key
+	}
+
+	return &task, nil
+}
@@ -0,0 +1,177 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/db"
+)
+
+// User errors
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrUserExists   = errors.New("user already exists")
+)
+
+// userColumns is the column list shared by every query that returns users
+const userColumns = `id, email, name, created_at, updated_at`
+
+// userForeignKeys names the constraints that reference the users table
+var userForeignKeys = map[string]bool{
+	"tasks_assignee_id_fkey":                     true,
+	"tasks_reporter_id_fkey":                     true,
+	"task_assignments_assignee_id_fkey":          true,
+	"task_assignments_previous_assignee_id_fkey": true,
+	"task_assignments_changed_by_fkey":           true,
+	"comments_author_id_fkey":                    true,
+	"attachments_uploaded_by_fkey":               true,
+	"views_owner_id_fkey":                        true,
+}
+
+// UserRepo handles database operations for the users directory
+type UserRepo struct {
+	db *db.Pool
+}
+
+// NewUserRepo creates a new user repository
+func NewUserRepo(db *db.Pool) *UserRepo {
+	return &UserRepo{db: db}
+}
+
+// List returns every user ordered by name
+func (r *UserRepo) List(ctx context.Context) ([]domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users ORDER BY name, email`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("selecting users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []domain.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning user row: %w", err)
+		}
+		users = append(users, *user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating user rows: %w", err)
+	}
+
+	return users, nil
+}
+
+// Get retrieves a user by ID
+func (r *UserRepo) Get(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("selecting user: %w", err)
+	}
+
+	return user, nil
+}
+
+// Create inserts a new user
+func (r *UserRepo) Create(ctx context.Context, user *domain.User) error {
+	query := `
+		INSERT INTO users (id, email, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		user.ID,
+		user.Email,
+		user.Name,
+		user.CreatedAt,
+		user.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrUserExists
+		}
+		return fmt.Errorf("inserting user: %w", err)
+	}
+
+	return nil
+}
+
+// Update saves every field of an existing user
+func (r *UserRepo) Update(ctx context.Context, user *domain.User) error {
+	query := `
+		UPDATE users
+		SET email = $2, name = $3, updated_at = now()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		user.ID,
+		user.Email,
+		user.Name,
+	).Scan(&user.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		if isUniqueViolation(err) {
+			return ErrUserExists
+		}
+		return fmt.Errorf("updating user: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a user. Tasks they were assigned or reported keep existing
+// with the reference cleared.
+func (r *UserRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("deleting user: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// scanUser reads a row selected with userColumns into a domain.User
+func scanUser(row pgx.Row) (*domain.User, error) {
+	var user domain.User
+
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.Name,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// isUserForeignKeyViolation reports whether err is a foreign key violation
+// caused by a reference to a user that does not exist
+func isUserForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return isForeignKeyViolation(err) && errors.As(err, &pgErr) && userForeignKeys[pgErr.ConstraintName]
+}
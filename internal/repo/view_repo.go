@@ -0,0 +1,171 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/db"
+)
+
+// View errors
+var (
+	ErrViewNotFound = errors.New("view not found")
+	ErrViewExists   = errors.New("view already exists")
+)
+
+// viewColumns is the column list shared by every query that returns views
+const viewColumns = `id, owner_id, name, filter, sort, columns, shared, created_at, updated_at`
+
+// ViewRepo handles database operations for saved views
+type ViewRepo struct {
+	db *db.Pool
+}
+
+// NewViewRepo creates a new view repository
+func NewViewRepo(db *db.Pool) *ViewRepo {
+	return &ViewRepo{db: db}
+}
+
+// List returns the views owned by ownerID together with every shared view,
+// ordered by name. A nil ownerID lists the shared views only.
+func (r *ViewRepo) List(ctx context.Context, ownerID *uuid.UUID) ([]domain.View, error) {
+	query := `SELECT ` + viewColumns + ` FROM views WHERE shared OR owner_id = $1 ORDER BY name, id`
+
+	rows, err := r.db.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("selecting views: %w", err)
+	}
+	defer rows.Close()
+
+	views := []domain.View{}
+	for rows.Next() {
+		view, err := scanView(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning view row: %w", err)
+		}
+		views = append(views, *view)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating view rows: %w", err)
+	}
+
+	return views, nil
+}
+
+// Get retrieves a view by ID
+func (r *ViewRepo) Get(ctx context.Context, id uuid.UUID) (*domain.View, error) {
+	query := `SELECT ` + viewColumns + ` FROM views WHERE id = $1`
+
+	view, err := scanView(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrViewNotFound
+		}
+		return nil, fmt.Errorf("selecting view: %w", err)
+	}
+
+	return view, nil
+}
+
+// Create inserts a new view. View names are unique per owner.
+func (r *ViewRepo) Create(ctx context.Context, view *domain.View) error {
+	query := `
+		INSERT INTO views (id, owner_id, name, filter, sort, columns, shared, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		view.ID,
+		view.OwnerID,
+		view.Name,
+		view.Filter,
+		view.Sort,
+		view.Columns,
+		view.Shared,
+		view.CreatedAt,
+		view.UpdatedAt,
+	)
+	if err != nil {
+		if isUserForeignKeyViolation(err) {
+			return ErrUserNotFound
+		}
+		if isUniqueViolation(err) {
+			return ErrViewExists
+		}
+		return fmt.Errorf("inserting view: %w", err)
+	}
+
+	return nil
+}
+
+// Update saves the mutable fields of an existing view
+func (r *ViewRepo) Update(ctx context.Context, view *domain.View) error {
+	query := `
+		UPDATE views
+		SET name = $2, filter = $3, sort = $4, columns = $5, shared = $6, updated_at = now()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		view.ID,
+		view.Name,
+		view.Filter,
+		view.Sort,
+		view.Columns,
+		view.Shared,
+	).Scan(&view.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrViewNotFound
+		}
+		if isUniqueViolation(err) {
+			return ErrViewExists
+		}
+		return fmt.Errorf("updating view: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a view
+func (r *ViewRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM views WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("deleting view: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrViewNotFound
+	}
+
+	return nil
+}
+
+// scanView reads a row selected with viewColumns into a domain.View
+func scanView(row pgx.Row) (*domain.View, error) {
+	var view domain.View
+
+	err := row.Scan(
+		&view.ID,
+		&view.OwnerID,
+		&view.Name,
+		&view.Filter,
+		&view.Sort,
+		&view.Columns,
+		&view.Shared,
+		&view.CreatedAt,
+		&view.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &view, nil
+}
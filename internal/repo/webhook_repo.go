@@ -0,0 +1,364 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/db"
+)
+
+// Webhook errors
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+// webhookColumns is the column list shared by every query that returns webhooks
+const webhookColumns = `id, url, secret, events, active, created_at, updated_at`
+
+// deliveryColumns is the column list shared by every query that returns deliveries
+const deliveryColumns = `id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, delivered_at, created_at, updated_at`
+
+// WebhookRepo handles database operations for webhooks and their delivery queue
+type WebhookRepo struct {
+	db *db.Pool
+}
+
+// NewWebhookRepo creates a new webhook repository
+func NewWebhookRepo(db *db.Pool) *WebhookRepo {
+	return &WebhookRepo{db: db}
+}
+
+// List returns every webhook, oldest first
+func (r *WebhookRepo) List(ctx context.Context) ([]domain.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("selecting webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []domain.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning webhook row: %w", err)
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating webhook rows: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// Get retrieves a webhook by ID
+func (r *WebhookRepo) Get(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+	webhook, err := scanWebhook(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("selecting webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// Create inserts a new webhook
+func (r *WebhookRepo) Create(ctx context.Context, webhook *domain.Webhook) error {
+	query := `
+		INSERT INTO webhooks (id, url, secret, events, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		webhook.ID,
+		webhook.URL,
+		webhook.Secret,
+		eventTypeNames(webhook.Events),
+		webhook.Active,
+		webhook.CreatedAt,
+		webhook.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting webhook: %w", err)
+	}
+
+	return nil
+}
+
+// Update saves every field of an existing webhook
+func (r *WebhookRepo) Update(ctx context.Context, webhook *domain.Webhook) error {
+	query := `
+		UPDATE webhooks
+		SET url = $2, secret = $3, events = $4, active = $5, updated_at = now()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		webhook.ID,
+		webhook.URL,
+		webhook.Secret,
+		eventTypeNames(webhook.Events),
+		webhook.Active,
+	).Scan(&webhook.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrWebhookNotFound
+		}
+		return fmt.Errorf("updating webhook: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a webhook together with its deliveries
+func (r *WebhookRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("deleting webhook: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// Enqueue queues an event for delivery to every active webhook subscribed to
+// its type and returns the number of deliveries queued. Webhooks that already
+// have a delivery of the event are skipped.
+func (r *WebhookRepo) Enqueue(ctx context.Context, eventID uuid.UUID, eventType domain.TaskEventType, payload json.RawMessage) (int64, error) {
+	query := `
+		INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, status, next_attempt_at)
+		SELECT gen_random_uuid(), w.id, $1::uuid, $2::text, $3::jsonb, 'pending', now()
+		FROM webhooks w
+		WHERE w.active AND (cardinality(w.events) = 0 OR $2::text = ANY(w.events))
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`
+
+	result, err := r.db.Exec(ctx, query, eventID, string(eventType), string(payload))
+	if err != nil {
+		return 0, fmt.Errorf("queueing webhook deliveries: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// ListDeliveries returns the deliveries of a webhook, newest first
+func (r *WebhookRepo) ListDeliveries(ctx context.Context, webhookID uuid.UUID, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	var b whereBuilder
+	b.where("webhook_id = " + b.arg(webhookID))
+	if filter.Status != nil {
+		b.where("status = " + b.arg(string(*filter.Status)))
+	}
+
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries ` + b.clause() +
+		` ORDER BY created_at DESC, id DESC LIMIT ` + b.arg(filter.Limit)
+
+	return r.queryDeliveries(ctx, query, b.args...)
+}
+
+// GetDelivery retrieves a delivery of a webhook by ID
+func (r *WebhookRepo) GetDelivery(ctx context.Context, webhookID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE webhook_id = $1 AND id = $2`
+
+	delivery, err := scanDelivery(r.db.QueryRow(ctx, query, webhookID, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("selecting webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// ClaimDeliveries leases up to limit pending deliveries of active webhooks
+// that are due at now. Each claimed delivery counts an attempt and is hidden
+// from other workers until leaseUntil; rows another worker is claiming at the
+// same time are skipped rather than waited for.
+func (r *WebhookRepo) ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT d.id
+			FROM webhook_deliveries d
+			JOIN webhooks w ON w.id = d.webhook_id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= $1 AND w.active
+			ORDER BY d.next_attempt_at, d.created_at
+			LIMIT $3
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET attempts = d.attempts + 1, next_attempt_at = $2, updated_at = now()
+		FROM due
+		WHERE d.id = due.id
+		RETURNING ` + qualifyColumns("d", deliveryColumns)
+
+	return r.queryDeliveries(ctx, query, now, leaseUntil, limit)
+}
+
+// RecordAttempt saves the outcome of a delivery attempt
+func (r *WebhookRepo) RecordAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, next_attempt_at = $3, last_status_code = $4, last_error = $5, delivered_at = $6, updated_at = now()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		delivery.ID,
+		string(delivery.Status),
+		delivery.NextAttemptAt,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.DeliveredAt,
+	).Scan(&delivery.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrDeliveryNotFound
+		}
+		return fmt.Errorf("recording webhook delivery attempt: %w", err)
+	}
+
+	return nil
+}
+
+// Redeliver puts a delivery back in the queue with a fresh set of attempts
+func (r *WebhookRepo) Redeliver(ctx context.Context, webhookID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = now(), updated_at = now()
+		WHERE webhook_id = $1 AND id = $2
+		RETURNING ` + deliveryColumns
+
+	delivery, err := scanDelivery(r.db.QueryRow(ctx, query, webhookID, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("requeueing webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// queryDeliveries runs a query returning deliveryColumns and collects the rows
+func (r *WebhookRepo) queryDeliveries(ctx context.Context, query string, args ...any) ([]domain.WebhookDelivery, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("selecting webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning webhook delivery row: %w", err)
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating webhook delivery rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// scanWebhook reads a row selected with webhookColumns into a domain.Webhook
+func scanWebhook(row pgx.Row) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	var events []string
+
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.URL,
+		&webhook.Secret,
+		&events,
+		&webhook.Active,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Events = make([]domain.TaskEventType, len(events))
+	for i, e := range events {
+		webhook.Events[i] = domain.TaskEventType(e)
+	}
+
+	return &webhook, nil
+}
+
+// scanDelivery reads a row selected with deliveryColumns into a domain.WebhookDelivery
+func scanDelivery(row pgx.Row) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	var payload []byte
+	var statusCode pgtype.Int4
+	var lastError pgtype.Text
+	var deliveredAt pgtype.Timestamptz
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&statusCode,
+		&lastError,
+		&deliveredAt,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Payload = payload
+	if statusCode.Valid {
+		code := int(statusCode.Int32)
+		delivery.LastStatusCode = &code
+	}
+	if lastError.Valid {
+		msg := lastError.String
+		delivery.LastError = &msg
+	}
+	if deliveredAt.Valid {
+		at := deliveredAt.Time
+		delivery.DeliveredAt = &at
+	}
+
+	return &delivery, nil
+}
+
+// eventTypeNames converts event types to the text array stored in the database
+func eventTypeNames(events []domain.TaskEventType) []string {
+	names := make([]string, len(events))
+	for i, e := range events {
+		names[i] = string(e)
+	}
+	return names
+}
@@ -0,0 +1,109 @@
+// Package scheduler runs the service's periodic background jobs. Jobs are
+// expected to coordinate across replicas through the database themselves; the
+// scheduler only runs them on an interval and stops them on shutdown.
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"task-svc/internal/platform/metrics"
+)
+
+// Job is one run of a background job. It should return promptly once ctx is done.
+type Job func(ctx context.Context) error
+
+// Scheduler runs jobs on fixed intervals until it is stopped
+type Scheduler struct {
+	logger *slog.Logger
+	jobs   []scheduledJob
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type scheduledJob struct {
+	name     string
+	interval time.Duration
+	run      Job
+}
+
+// New creates a scheduler without any jobs
+func New(logger *slog.Logger) *Scheduler {
+	return &Scheduler{logger: logger}
+}
+
+// Add registers a job that runs every interval, starting right away. Jobs
+// must be added before Start.
+func (s *Scheduler) Add(name string, interval time.Duration, job Job) {
+	s.jobs = append(s.jobs, scheduledJob{name: name, interval: interval, run: job})
+}
+
+// Start runs every job in its own goroutine until ctx is done or Stop is called
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job scheduledJob) {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Stop cancels the running jobs and waits for them to return, or for ctx to
+// be done, whichever comes first
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// loop runs a job on its interval. Runs never overlap: a run that takes longer
+// than the interval delays the next one.
+func (s *Scheduler) loop(ctx context.Context, job scheduledJob) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for ctx.Err() == nil {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce runs a job and records its outcome
+func (s *Scheduler) runOnce(ctx context.Context, job scheduledJob) {
+	start := time.Now()
+	err := job.run(ctx)
+	if ctx.Err() != nil {
+		// Shutting down; the run was interrupted rather than failed
+		return
+	}
+
+	if err != nil {
+		metrics.JobRunsTotal.WithLabelValues(job.name, "error").Inc()
+		s.logger.Error("Background job failed", "job", job.name, "duration", time.Since(start), "error", err)
+		return
+	}
+	metrics.JobRunsTotal.WithLabelValues(job.name, "success").Inc()
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"task-svc/internal/config"
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/storage"
+	"task-svc/internal/repo"
+)
+
+// maxFilenameLength bounds stored file names, in bytes
+const maxFilenameLength = 255
+
+// AttachmentService defines the interface for task attachment operations
+type AttachmentService interface {
+	List(ctx context.Context, taskID uuid.UUID) ([]domain.Attachment, error)
+	Get(ctx context.Context, taskID, id uuid.UUID) (*domain.Attachment, error)
+	Open(ctx context.Context, taskID, id uuid.UUID) (*domain.Attachment, io.ReadCloser, error)
+	Upload(ctx context.Context, taskID uuid.UUID, req domain.UploadAttachmentRequest) (*domain.Attachment, error)
+	Delete(ctx context.Context, taskID, id uuid.UUID) error
+}
+
+// attachmentService implements the AttachmentService interface
+type attachmentService struct {
+	repository AttachmentRepository
+	tasks      TaskRepository
+	blobs      storage.BlobStore
+	config     config.AttachmentConfig
+}
+
+// NewAttachmentService creates a new attachment service storing contents in blobs
+func NewAttachmentService(attachments *repo.AttachmentRepo, tasks *repo.TaskRepo, blobs storage.BlobStore, cfg config.AttachmentConfig) AttachmentService {
+	return &attachmentService{
+		repository: attachments,
+		tasks:      tasks,
+		blobs:      blobs,
+		config:     cfg,
+	}
+}
+
+// List retrieves a task's attachments, oldest first
+func (s *attachmentService) List(ctx context.Context, taskID uuid.UUID) ([]domain.Attachment, error) {
+	if _, err := s.tasks.Get(ctx, taskID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return s.repository.List(ctx, taskID)
+}
+
+// Get retrieves the metadata of an attachment
+func (s *attachmentService) Get(ctx context.Context, taskID, id uuid.UUID) (*domain.Attachment, error) {
+	attachment, err := s.repository.Get(ctx, taskID, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrAttachmentNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// Open retrieves an attachment together with its content. The caller closes the reader.
+func (s *attachmentService) Open(ctx context.Context, taskID, id uuid.UUID) (*domain.Attachment, io.ReadCloser, error) {
+	attachment, err := s.Get(ctx, taskID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobs.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening attachment %s: %w", attachment.ID, err)
+	}
+
+	return attachment, content, nil
+}
+
+// Upload checks the file against the configured limits, stores its content in
+// the blob store and records its metadata. The content is spooled to a
+// temporary file first so that its size and checksum are known before it is stored.
+func (s *attachmentService) Upload(ctx context.Context, taskID uuid.UUID, req domain.UploadAttachmentRequest) (*domain.Attachment, error) {
+	if _, err := s.tasks.Get(ctx, taskID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	spool, err := os.CreateTemp("", "task-attachment-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating upload spool: %w", err)
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(spool, hash), io.LimitReader(req.Content, s.config.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading upload: %w", err)
+	}
+	if size > s.config.MaxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+
+	// Sniff the content for clients that do not declare a type
+	head := make([]byte, 512)
+	n, err := spool.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("reading upload spool: %w", err)
+	}
+	contentType, err := s.contentType(req.ContentType, http.DetectContentType(head[:n]))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("rewinding upload spool: %w", err)
+	}
+
+	var uploader *uuid.UUID
+	if actor, ok := domain.ActorFromContext(ctx); ok {
+		uploader = &actor
+	}
+
+	id := uuid.New()
+	attachment := &domain.Attachment{
+		ID:             id,
+		TaskID:         taskID,
+		Filename:       sanitizeFilename(req.Filename),
+		ContentType:    contentType,
+		Size:           size,
+		ChecksumSHA256: hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:     taskID.String() + "/" + id.String(),
+		UploadedBy:     uploader,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	blob := storage.Blob{Size: size, ContentType: contentType, SHA256: attachment.ChecksumSHA256}
+	if err := s.blobs.Put(ctx, attachment.StorageKey, spool, blob); err != nil {
+		return nil, fmt.Errorf("storing attachment content: %w", err)
+	}
+
+	if err := s.repository.Create(ctx, attachment); err != nil {
+		// Do not leave unreferenced content behind
+		_ = s.blobs.Delete(ctx, attachment.StorageKey)
+
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			return nil, ErrNotFound
+		case errors.Is(err, repo.ErrUserNotFound):
+			return nil, ErrUnknownUser
+		}
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// Delete removes an attachment. The content goes first so that a failure
+// leaves the attachment listed and the delete can be retried.
+func (s *attachmentService) Delete(ctx context.Context, taskID, id uuid.UUID) error {
+	attachment, err := s.Get(ctx, taskID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.blobs.Delete(ctx, attachment.StorageKey); err != nil {
+		return fmt.Errorf("deleting attachment content: %w", err)
+	}
+
+	if _, err := s.repository.Delete(ctx, taskID, id); err != nil {
+		if errors.Is(err, repo.ErrAttachmentNotFound) {
+			return ErrAttachmentNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// contentType picks the declared type, or the sniffed one when the client sent
+// none or only application/octet-stream, and checks it against the allow-list
+func (s *attachmentService) contentType(declared, sniffed string) (string, error) {
+	contentType := sniffed
+	if mediaType, _, err := mime.ParseMediaType(declared); err == nil && mediaType != "application/octet-stream" {
+		contentType = declared
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !s.allowed(mediaType) {
+		return "", ErrAttachmentTypeNotAllowed
+	}
+
+	return contentType, nil
+}
+
+// allowed reports whether mediaType matches an entry of the allow-list
+func (s *attachmentService) allowed(mediaType string) bool {
+	for _, pattern := range s.config.AllowedTypes {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		switch {
+		case pattern == "*/*", pattern == mediaType:
+			return true
+		case strings.HasSuffix(pattern, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(pattern, "*")):
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeFilename keeps the last path element of name, as some browsers send
+// the full client-side path, and bounds its length
+func sanitizeFilename(name string) string {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name))
+
+	for len(name) > maxFilenameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+
+	if name == "" || name == "." || name == ".." {
+		return "attachment"
+	}
+	return name
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/repo"
+)
+
+// CommentService defines the interface for task comment operations
+type CommentService interface {
+	List(ctx context.Context, taskID uuid.UUID, filter domain.CommentFilter) (*domain.CommentList, error)
+	Get(ctx context.Context, taskID, id uuid.UUID) (*domain.Comment, error)
+	Create(ctx context.Context, taskID uuid.UUID, req domain.CreateCommentRequest) (*domain.Comment, error)
+	Update(ctx context.Context, taskID, id uuid.UUID, req domain.UpdateCommentRequest) (*domain.Comment, error)
+	Delete(ctx context.Context, taskID, id uuid.UUID) error
+}
+
+// commentService implements the CommentService interface
+type commentService struct {
+	repository CommentRepository
+	tasks      TaskRepository
+}
+
+// NewCommentService creates a new comment service
+func NewCommentService(comments *repo.CommentRepo, tasks *repo.TaskRepo) CommentService {
+	return &commentService{repository: comments, tasks: tasks}
+}
+
+// List retrieves a page of a task's comments, oldest first
+func (s *commentService) List(ctx context.Context, taskID uuid.UUID, filter domain.CommentFilter) (*domain.CommentList, error) {
+	if err := s.checkTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return s.repository.List(ctx, taskID, filter)
+}
+
+// Get retrieves a comment of a task
+func (s *commentService) Get(ctx context.Context, taskID, id uuid.UUID) (*domain.Comment, error) {
+	comment, err := s.repository.Get(ctx, taskID, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrCommentNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return comment, nil
+}
+
+// Create adds a comment, or a reply when ParentID is set, written by the acting
+// user. Tasks in the trash take no comments.
+func (s *commentService) Create(ctx context.Context, taskID uuid.UUID, req domain.CreateCommentRequest) (*domain.Comment, error) {
+	if err := s.checkTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+
+	var author *uuid.UUID
+	if actor, ok := domain.ActorFromContext(ctx); ok {
+		author = &actor
+	}
+
+	comment := &domain.Comment{
+		ID:        uuid.New(),
+		TaskID:    taskID,
+		ParentID:  req.ParentID,
+		AuthorID:  author,
+		Body:      req.Body,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Version:   1,
+	}
+
+	if err := s.repository.Create(ctx, comment); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+			return nil, ErrNotFound
+		case errors.Is(err, repo.ErrCommentParentNotFound):
+			return nil, ErrCommentParentNotFound
+		case errors.Is(err, repo.ErrUserNotFound):
+			return nil, ErrUnknownUser
+		}
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// Update edits the body of a comment with optimistic locking
+func (s *commentService) Update(ctx context.Context, taskID, id uuid.UUID, req domain.UpdateCommentRequest) (*domain.Comment, error) {
+	comment, err := s.Get(ctx, taskID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.Body = req.Body
+	comment.Version = req.Version
+
+	if err := s.repository.Update(ctx, comment); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrCommentNotFound):
+			return nil, ErrCommentNotFound
+		case errors.Is(err, repo.ErrVersionConflict):
+			return nil, ErrVersionConflict
+		}
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// Delete removes a comment, leaving a tombstone so that its replies stay threaded
+func (s *commentService) Delete(ctx context.Context, taskID, id uuid.UUID) error {
+	if err := s.repository.Delete(ctx, taskID, id); err != nil {
+		if errors.Is(err, repo.ErrCommentNotFound) {
+			return ErrCommentNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// checkTask reports ErrNotFound when the task does not exist or is in the trash
+func (s *commentService) checkTask(ctx context.Context, taskID uuid.UUID) error {
+	if _, err := s.tasks.Get(ctx, taskID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
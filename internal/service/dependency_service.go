@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/repo"
+)
+
+// AddDependency marks the task with the given ID as blocked by blockerID
+func (s *taskService) AddDependency(ctx context.Context, id, blockerID uuid.UUID) (*domain.Dependency, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	dep, err := s.repository.AddDependency(ctx, blockerID, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrDependencyCycle):
+			return nil, ErrDependencyCycle
+		case errors.Is(err, repo.ErrDependencyExists):
+			return nil, ErrDependencyExists
+		case errors.Is(err, repo.ErrNotFound):
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return dep, nil
+}
+
+// RemoveDependency removes the blockerID edge from the task with the given ID
+func (s *taskService) RemoveDependency(ctx context.Context, id, blockerID uuid.UUID) error {
+	if err := s.repository.RemoveDependency(ctx, blockerID, id); err != nil {
+		if errors.Is(err, repo.ErrDependencyNotFound) {
+			return ErrDependencyNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Dependencies lists the tasks blocking, and blocked by, the task with the given ID
+func (s *taskService) Dependencies(ctx context.Context, id uuid.UUID) (*domain.TaskDependencies, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	blockedBy, err := s.repository.ListBlockers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := s.repository.ListBlocked(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TaskDependencies{BlockedBy: blockedBy, Blocks: blocks}, nil
+}
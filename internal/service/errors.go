@@ -1,10 +1,125 @@
 package service
 
-import "errors"
+import (
+    "errors"
+    "fmt"
+
+    "github.com/google/uuid"
+
+    "task-svc/internal/domain"
+)
 
 var (
     ErrNotFound        = errors.New("task not found")
     ErrVersionConflict = errors.New("version conflict")
+    ErrVersionNotFound = errors.New("task version not found")
+    ErrParentNotFound  = errors.New("parent task not found")
+    ErrParentCycle     = errors.New("parent would create a cycle")
+    ErrParentDeleted   = errors.New("parent task is deleted")
+    ErrParentDone      = errors.New("parent task is done")
+    ErrOpenSubtasks    = errors.New("task has open subtasks")
+    ErrBlocked         = errors.New("task is blocked by unfinished dependencies")
+
+    ErrInvalidTransition = errors.New("status transition not allowed")
+    ErrUnknownStatus     = errors.New("unknown status")
+    ErrUnknownPriority   = errors.New("unknown priority")
+    ErrUnknownSeverity   = errors.New("unknown severity")
+
+    ErrSearchQueryRequired = errors.New("search query is required")
+
+    ErrInvalidRecurrence      = errors.New("invalid recurrence rule")
+    ErrRecurrenceNeedsDueDate = errors.New("recurring tasks need a due date")
+    ErrNotRecurring           = errors.New("task does not recur")
+
+    ErrDependencyCycle    = errors.New("dependency would create a cycle")
+    ErrDependencyExists   = errors.New("dependency already exists")
+    ErrDependencyNotFound = errors.New("dependency not found")
+
+    ErrStatusNotFound = errors.New("status not found")
+    ErrStatusExists   = errors.New("status already exists")
+    ErrStatusInUse    = errors.New("status is used by tasks")
+    ErrLastTodoStatus = errors.New("at least one todo status is required")
+
+    ErrLabelNotFound = errors.New("label not found")
+    ErrLabelExists   = errors.New("label already exists")
+    ErrUnknownLabel  = errors.New("unknown label")
+
+    ErrUserNotFound = errors.New("user not found")
+    ErrUserExists   = errors.New("user already exists")
+    ErrUnknownUser  = errors.New("unknown user")
+
+    ErrProjectNotFound = errors.New("project not found")
+    ErrProjectExists   = errors.New("project already exists")
+    ErrProjectArchived = errors.New("project is archived")
+    ErrProjectNotEmpty = errors.New("project still has tasks")
+    ErrProjectMismatch = errors.New("parent task belongs to another project")
+    ErrUnknownProject  = errors.New("unknown project")
+
+    ErrViewNotFound  = errors.New("view not found")
+    ErrViewExists    = errors.New("view already exists")
+    ErrViewForbidden = errors.New("view belongs to another user")
+    ErrActorRequired = errors.New("acting user is required")
+
+    ErrCommentNotFound       = errors.New("comment not found")
+    ErrCommentParentNotFound = errors.New("parent comment not found")
+
+    ErrAttachmentNotFound       = errors.New("attachment not found")
+    ErrAttachmentTooLarge       = errors.New("attachment is too large")
+    ErrAttachmentTypeNotAllowed = errors.New("attachment content type is not allowed")
+
+    ErrWebhookNotFound   = errors.New("webhook not found")
+    ErrDeliveryNotFound  = errors.New("webhook delivery not found")
+    ErrInvalidWebhookURL = errors.New("webhook URL must be an absolute http or https URL")
+    ErrUnknownEventType  = errors.New("unknown event type")
 )
 
+// BlockedError reports the unfinished blockers that prevent a task from starting
+// or completing. It matches ErrBlocked with errors.Is.
+type BlockedError struct {
+    BlockerIDs []uuid.UUID
+}
+
+func (e *BlockedError) Error() string {
+    return fmt.Sprintf("task is blocked by %d unfinished task(s)", len(e.BlockerIDs))
+}
+
+// Is makes errors.Is(err, ErrBlocked) succeed for any BlockedError
+func (e *BlockedError) Is(target error) bool {
+    return target == ErrBlocked
+}
+
+// TransitionError reports a status change rejected by the workflow. From is empty
+// when the status was rejected as the initial status of a new task. It matches
+// ErrInvalidTransition with errors.Is.
+type TransitionError struct {
+    From    domain.Status
+    To      domain.Status
+    Allowed []domain.Status
+}
+
+func (e *TransitionError) Error() string {
+    if e.From == "" {
+        return fmt.Sprintf("tasks cannot be created as %s", e.To)
+    }
+    return fmt.Sprintf("cannot move task from %s to %s", e.From, e.To)
+}
+
+// Is makes errors.Is(err, ErrInvalidTransition) succeed for any TransitionError
+func (e *TransitionError) Is(target error) bool {
+    return target == ErrInvalidTransition
+}
+
+// RecurrenceError reports an RRULE that could not be parsed. It matches
+// ErrInvalidRecurrence with errors.Is.
+type RecurrenceError struct {
+    Err error
+}
+
+func (e *RecurrenceError) Error() string {
+    return e.Err.Error()
+}
 
+// Is makes errors.Is(err, ErrInvalidRecurrence) succeed for any RecurrenceError
+func (e *RecurrenceError) Is(target error) bool {
+    return target == ErrInvalidRecurrence
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"task-svc/internal/config"
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/metrics"
+	"task-svc/internal/repo"
+)
+
+// maxListenBackoff bounds the wait between attempts to listen for events again
+const maxListenBackoff = 30 * time.Second
+
+// EventStream defines the interface for following task events as they happen
+type EventStream interface {
+	// Subscribe registers a subscriber for the events matching filter. With a
+	// lastEventID, the buffered events recorded after it are queued first;
+	// resumed is false when some of them are no longer buffered.
+	Subscribe(ctx context.Context, filter domain.EventFilter, lastEventID int64) (sub *Subscription, resumed bool, err error)
+	// Run follows the events announced by the database until ctx is done,
+	// then ends every subscription
+	Run(ctx context.Context)
+}
+
+// Subscription delivers the events of a stream subscriber. Events is closed
+// when the subscriber falls too far behind or the subscription is closed.
+type Subscription struct {
+	Events <-chan domain.TaskEvent
+	close  func()
+}
+
+// Close ends the subscription
+func (s *Subscription) Close() {
+	s.close()
+}
+
+// eventStream implements the EventStream interface
+type eventStream struct {
+	repository   OutboxRepository
+	statuses     StatusRepository
+	logger       *slog.Logger
+	bufferSize   int
+	clientBuffer int
+
+	mu          sync.Mutex
+	buffer      []domain.TaskEvent
+	seen        map[int64]struct{}
+	lastSeq     int64
+	floor       int64 // events up to floor may be missing from the buffer
+	subscribers map[*subscriber]struct{}
+}
+
+// subscriber is a client of the stream with its pending events
+type subscriber struct {
+	filter domain.EventFilter
+	events chan domain.TaskEvent
+}
+
+// NewEventStream creates a new event stream fed by the outbox notifications.
+// Run must be called for it to receive events.
+func NewEventStream(outbox *repo.OutboxRepo, statuses *repo.StatusRepo, cfg config.EventStreamConfig, logger *slog.Logger) EventStream {
+	return &eventStream{
+		repository:   outbox,
+		statuses:     statuses,
+		logger:       logger,
+		bufferSize:   max(cfg.BufferSize, 1),
+		clientBuffer: max(cfg.ClientBuffer, 1),
+		seen:         make(map[int64]struct{}),
+		subscribers:  make(map[*subscriber]struct{}),
+	}
+}
+
+// Subscribe registers a subscriber for the events matching filter
+func (s *eventStream) Subscribe(ctx context.Context, filter domain.EventFilter, lastEventID int64) (*Subscription, bool, error) {
+	if len(filter.Statuses) > 0 {
+		statuses, err := s.statuses.List(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, status := range filter.Statuses {
+			if _, ok := statuses.Lookup(status); !ok {
+				return nil, false, ErrUnknownStatus
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var replay []domain.TaskEvent
+	resumed := true
+	if lastEventID > 0 {
+		replay, resumed = s.replay(filter, lastEventID)
+	}
+
+	sub := &subscriber{filter: filter, events: make(chan domain.TaskEvent, s.clientBuffer+len(replay))}
+	for _, event := range replay {
+		sub.events <- event
+	}
+	s.subscribers[sub] = struct{}{}
+	metrics.EventStreamSubscribers.Inc()
+
+	return &Subscription{Events: sub.events, close: func() { s.unsubscribe(sub) }}, resumed, nil
+}
+
+// Run listens for the events announced by the database, listening again
+// with a growing delay whenever the connection fails. Each time it starts
+// listening, it first catches up on the events recorded meanwhile.
+func (s *eventStream) Run(ctx context.Context) {
+	defer s.closeAll()
+
+	delay := time.Second
+	for {
+		err := s.repository.Listen(ctx,
+			func() {
+				delay = time.Second
+				s.catchUp(ctx)
+			},
+			func(seq int64) { s.receive(ctx, seq) },
+		)
+		if ctx.Err() != nil {
+			return
+		}
+		s.logger.Error("Listening for task events failed", "error", err, "retry_in", delay.String())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxListenBackoff)
+	}
+}
+
+// catchUp buffers and broadcasts the events recorded since the last one seen
+func (s *eventStream) catchUp(ctx context.Context) {
+	s.mu.Lock()
+	after := s.lastSeq
+	s.mu.Unlock()
+
+	events, err := s.repository.Recent(ctx, after, s.bufferSize)
+	if err != nil {
+		s.logger.Error("Failed to catch up on task events", "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(events) == s.bufferSize {
+		// Older events may have been recorded beyond what was read
+		s.floor = max(s.floor, events[0].Seq-1)
+	}
+	for _, event := range events {
+		s.broadcast(event)
+	}
+}
+
+// receive buffers and broadcasts an announced event
+func (s *eventStream) receive(ctx context.Context, seq int64) {
+	event, err := s.repository.Event(ctx, seq)
+	if err != nil {
+		if !errors.Is(err, repo.ErrEventNotFound) {
+			s.logger.Error("Failed to read task event", "seq", seq, "error", err)
+		}
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.broadcast(*event)
+}
+
+// broadcast adds an event to the buffer and queues it for the matching
+// subscribers. A subscriber whose queue is full is dropped rather than
+// holding up the others. s.mu must be held.
+func (s *eventStream) broadcast(event domain.TaskEvent) {
+	if _, ok := s.seen[event.Seq]; ok {
+		return
+	}
+
+	s.buffer = append(s.buffer, event)
+	s.seen[event.Seq] = struct{}{}
+	s.lastSeq = max(s.lastSeq, event.Seq)
+	if len(s.buffer) > s.bufferSize {
+		evicted := s.buffer[0]
+		s.buffer = s.buffer[1:]
+		delete(s.seen, evicted.Seq)
+		s.floor = max(s.floor, evicted.Seq)
+	}
+
+	for sub := range s.subscribers {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			s.logger.Warn("Dropping slow event stream subscriber", "seq", event.Seq)
+			s.remove(sub)
+		}
+	}
+}
+
+// replay returns the buffered events matching filter that were recorded
+// after the event with lastEventID, and whether all of them are still
+// buffered. s.mu must be held.
+func (s *eventStream) replay(filter domain.EventFilter, lastEventID int64) ([]domain.TaskEvent, bool) {
+	// The buffer is in the order events were announced, which may differ from
+	// seq order, so resume after the position of the last event when known
+	next, found := 0, false
+	for i, event := range s.buffer {
+		if event.Seq == lastEventID {
+			next, found = i+1, true
+			break
+		}
+	}
+
+	var replay []domain.TaskEvent
+	for _, event := range s.buffer[next:] {
+		if (found || event.Seq > lastEventID) && filter.Matches(event) {
+			replay = append(replay, event)
+		}
+	}
+	return replay, found || lastEventID >= s.floor
+}
+
+// unsubscribe removes a subscriber unless it was dropped already
+func (s *eventStream) unsubscribe(sub *subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(sub)
+}
+
+// closeAll ends every subscription
+func (s *eventStream) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subscribers {
+		s.remove(sub)
+	}
+}
+
+// remove closes a subscriber's queue and forgets it. s.mu must be held.
+func (s *eventStream) remove(sub *subscriber) {
+	if _, ok := s.subscribers[sub]; !ok {
+		return
+	}
+	delete(s.subscribers, sub)
+	close(sub.events)
+	metrics.EventStreamSubscribers.Dec()
+}
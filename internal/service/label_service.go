@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/repo"
+)
+
+// defaultLabelColor is used for labels created without a color
+const defaultLabelColor = "#9e9e9e"
+
+// LabelService defines the interface for label operations
+type LabelService interface {
+	List(ctx context.Context) ([]domain.Label, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.Label, error)
+	Create(ctx context.Context, req domain.CreateLabelRequest) (*domain.Label, error)
+	Patch(ctx context.Context, id uuid.UUID, req domain.PatchLabelRequest) (*domain.Label, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// labelService implements the LabelService interface
+type labelService struct {
+	repository LabelRepository
+}
+
+// NewLabelService creates a new label service
+func NewLabelService(repo *repo.LabelRepo) LabelService {
+	return &labelService{repository: repo}
+}
+
+// List retrieves every label
+func (s *labelService) List(ctx context.Context) ([]domain.Label, error) {
+	return s.repository.List(ctx)
+}
+
+// Get retrieves a label by ID
+func (s *labelService) Get(ctx context.Context, id uuid.UUID) (*domain.Label, error) {
+	label, err := s.repository.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrLabelNotFound) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, err
+	}
+	return label, nil
+}
+
+// Create adds a label
+func (s *labelService) Create(ctx context.Context, req domain.CreateLabelRequest) (*domain.Label, error) {
+	now := time.Now().UTC()
+
+	color := defaultLabelColor
+	if req.Color != nil {
+		color = *req.Color
+	}
+
+	label := &domain.Label{
+		ID:          uuid.New(),
+		Name:        req.Name,
+		Color:       color,
+		Description: req.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.repository.Create(ctx, label); err != nil {
+		if errors.Is(err, repo.ErrLabelExists) {
+			return nil, ErrLabelExists
+		}
+		return nil, err
+	}
+
+	return label, nil
+}
+
+// Patch partially updates a label. Renaming a label renames it on every task.
+func (s *labelService) Patch(ctx context.Context, id uuid.UUID, req domain.PatchLabelRequest) (*domain.Label, error) {
+	label, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		label.Name = *req.Name
+	}
+	if req.Color != nil {
+		label.Color = *req.Color
+	}
+	if req.Description != nil {
+		label.Description = req.Description
+	}
+
+	if err := s.repository.Update(ctx, label); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrLabelNotFound):
+			return nil, ErrLabelNotFound
+		case errors.Is(err, repo.ErrLabelExists):
+			return nil, ErrLabelExists
+		}
+		return nil, err
+	}
+
+	return label, nil
+}
+
+// Delete removes a label and detaches it from every task
+func (s *labelService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.repository.Delete(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrLabelNotFound) {
+			return ErrLabelNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"task-svc/internal/config"
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/metrics"
+	"task-svc/internal/repo"
+)
+
+// EventSink receives the task events relayed from the outbox. Events arrive
+// in the order they were recorded, at least once: a sink may see an event
+// again after a failed or interrupted relay and should drop it by its ID.
+type EventSink interface {
+	// Name identifies the sink in logs and configuration
+	Name() string
+	Publish(ctx context.Context, event domain.TaskEvent) error
+}
+
+// OutboxRelay defines the interface for relaying outbox events to the sinks
+type OutboxRelay interface {
+	// Relay publishes the unpublished events to every sink and returns how
+	// many were relayed
+	Relay(ctx context.Context) (int, error)
+}
+
+// outboxRelay implements the OutboxRelay interface
+type outboxRelay struct {
+	repository OutboxRepository
+	sinks      []EventSink
+	batchSize  int
+	retention  time.Duration
+}
+
+// NewOutboxRelay creates a new relay publishing outbox events to sinks
+func NewOutboxRelay(outbox *repo.OutboxRepo, sinks []EventSink, cfg config.OutboxConfig) OutboxRelay {
+	return &outboxRelay{
+		repository: outbox,
+		sinks:      sinks,
+		batchSize:  max(cfg.BatchSize, 1),
+		retention:  cfg.Retention,
+	}
+}
+
+// Relay hands the unpublished events batch by batch to each sink in turn. An
+// event is marked published once every sink has accepted it; the first
+// failure stops the run, so that no later event overtakes it, and the event
+// is relayed again on the next run, to every sink. Published events are
+// purged once they are older than the retention.
+func (s *outboxRelay) Relay(ctx context.Context) (int, error) {
+	relayed := 0
+	for ctx.Err() == nil {
+		var failure error
+		var published []domain.TaskEventType
+		n, err := s.repository.Relay(ctx, s.batchSize, func(ctx context.Context, events []domain.TaskEvent) int {
+			for i, event := range events {
+				for _, sink := range s.sinks {
+					if err := sink.Publish(ctx, event); err != nil {
+						failure = fmt.Errorf("publishing %s event %s to %s: %w", event.Type, event.ID, sink.Name(), err)
+						return i
+					}
+				}
+				published = append(published, event.Type)
+			}
+			return len(events)
+		})
+		if err != nil {
+			return relayed, err
+		}
+
+		relayed += n
+		for _, eventType := range published {
+			metrics.OutboxEventsRelayedTotal.WithLabelValues(string(eventType)).Inc()
+		}
+		if failure != nil {
+			return relayed, failure
+		}
+		if n < s.batchSize {
+			break
+		}
+	}
+
+	if s.retention > 0 {
+		if _, err := s.repository.PurgePublished(ctx, time.Now().UTC().Add(-s.retention)); err != nil {
+			return relayed, err
+		}
+	}
+
+	return relayed, nil
+}
+
+// LogSink publishes events by writing them to the application log
+type LogSink struct {
+	Logger *slog.Logger
+}
+
+// Name identifies the log as an event sink
+func (LogSink) Name() string {
+	return "log"
+}
+
+// Publish logs the event
+func (s LogSink) Publish(_ context.Context, event domain.TaskEvent) error {
+	s.Logger.Info("Task event",
+		"event_id", event.ID,
+		"type", event.Type,
+		"task_id", event.Task.ID,
+		"status", event.Task.Status,
+		"occurred_at", event.OccurredAt,
+	)
+	return nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/repo"
+)
+
+// ProjectService defines the interface for project operations
+type ProjectService interface {
+	List(ctx context.Context, includeArchived bool) ([]domain.Project, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.Project, error)
+	GetByKey(ctx context.Context, key string) (*domain.Project, error)
+	Create(ctx context.Context, req domain.CreateProjectRequest) (*domain.Project, error)
+	Patch(ctx context.Context, id uuid.UUID, req domain.PatchProjectRequest) (*domain.Project, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// projectService implements the ProjectService interface
+type projectService struct {
+	repository ProjectRepository
+}
+
+// NewProjectService creates a new project service
+func NewProjectService(repo *repo.ProjectRepo) ProjectService {
+	return &projectService{repository: repo}
+}
+
+// List retrieves projects, optionally including archived ones
+func (s *projectService) List(ctx context.Context, includeArchived bool) ([]domain.Project, error) {
+	return s.repository.List(ctx, includeArchived)
+}
+
+// Get retrieves a project by ID
+func (s *projectService) Get(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
+	project, err := s.repository.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrProjectNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	return project, nil
+}
+
+// GetByKey retrieves a project by its key, ignoring case
+func (s *projectService) GetByKey(ctx context.Context, key string) (*domain.Project, error) {
+	project, err := s.repository.GetByKey(ctx, strings.ToUpper(key))
+	if err != nil {
+		if errors.Is(err, repo.ErrProjectNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	return project, nil
+}
+
+// Create adds a project
+func (s *projectService) Create(ctx context.Context, req domain.CreateProjectRequest) (*domain.Project, error) {
+	now := time.Now().UTC()
+
+	project := &domain.Project{
+		ID:          uuid.New(),
+		Key:         strings.ToUpper(req.Key),
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.repository.Create(ctx, project); err != nil {
+		if errors.Is(err, repo.ErrProjectExists) {
+			return nil, ErrProjectExists
+		}
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// Patch partially updates a project. Archiving a project keeps its tasks
+// readable but stops new tasks from being created in it.
+func (s *projectService) Patch(ctx context.Context, id uuid.UUID, req domain.PatchProjectRequest) (*domain.Project, error) {
+	project, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		project.Name = *req.Name
+	}
+	if req.Description != nil {
+		project.Description = req.Description
+	}
+	if req.Archived != nil {
+		project.Archived = *req.Archived
+	}
+
+	if err := s.repository.Update(ctx, project); err != nil {
+		if errors.Is(err, repo.ErrProjectNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// Delete removes an empty project
+func (s *projectService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.repository.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrProjectNotFound):
+			return ErrProjectNotFound
+		case errors.Is(err, repo.ErrProjectNotEmpty):
+			return ErrProjectNotEmpty
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"task-svc/internal/domain"
+	"task-svc/pkg/rrule"
+)
+
+// Occurrences previews the due dates of the next n occurrences of a recurring task
+func (s *taskService) Occurrences(ctx context.Context, id uuid.UUID, n int) ([]time.Time, error) {
+	task, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task.Recurrence == nil || task.DueDate == nil {
+		return nil, ErrNotRecurring
+	}
+
+	rule, err := rrule.Parse(*task.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule.Occurrences(task.DueDate.UTC(), n), nil
+}
+
+// nextOccurrence returns the occurrence that follows a recurring task that a
+// change moved from previous into a status of the done category, whatever
+// that status is named in the task's project. It is due on the rule's next
+// date after the task's due date, copies the task and starts in the default
+// status; its rule counts down COUNT so that the series ends where the
+// original rule did. It returns nil when the task does not recur, did not
+// just complete or its series has ended. The repository creates the
+// occurrence in the transaction that saves the change, skipping it if it
+// already exists or if the project has been archived meanwhile.
+func (s *taskService) nextOccurrence(ctx context.Context, task *domain.Task, previous domain.Status) (*domain.Task, error) {
+	if task.Recurrence == nil || task.DueDate == nil || task.Status == previous {
+		return nil, nil
+	}
+
+	statuses, err := s.projectStatuses(ctx, task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if statuses.Category(previous) == domain.CategoryDone || statuses.Category(task.Status) != domain.CategoryDone {
+		return nil, nil
+	}
+
+	rule, err := rrule.Parse(*task.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	dueDate, ok := rule.Next(task.DueDate.UTC())
+	if !ok {
+		return nil, nil
+	}
+	if rule.Count > 0 {
+		rule.Count--
+	}
+	recurrence := rule.String()
+
+	status := task.Status
+	if def, ok := statuses.First(domain.CategoryTodo); ok {
+		status = def.Name
+	}
+
+	now := time.Now().UTC()
+	return &domain.Task{
+		ID:           uuid.New(),
+		ProjectID:    task.ProjectID,
+		Title:        task.Title,
+		Description:  task.Description,
+		Status:       status,
+		Priority:     task.Priority,
+		Severity:     task.Severity,
+		DueDate:      &dueDate,
+		ParentID:     task.ParentID,
+		AssigneeID:   task.AssigneeID,
+		ReporterID:   task.ReporterID,
+		Labels:       task.Labels,
+		Recurrence:   &recurrence,
+		RecursFromID: &task.ID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Version:      1,
+	}, nil
+}
+
+// normalizeRecurrence checks an RRULE and returns it in canonical form. An
+// empty or missing rule means the task does not recur.
+func normalizeRecurrence(recurrence *string, dueDate *time.Time) (*string, error) {
+	if recurrence == nil || *recurrence == "" {
+		return nil, nil
+	}
+
+	rule, err := rrule.Parse(*recurrence)
+	if err != nil {
+		return nil, &RecurrenceError{Err: err}
+	}
+	if dueDate == nil {
+		return nil, ErrRecurrenceNeedsDueDate
+	}
+
+	canonical := rule.String()
+	return &canonical, nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"task-svc/internal/config"
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/metrics"
+	"task-svc/internal/repo"
+)
+
+// ReminderNotifier delivers reminders once they have fired
+type ReminderNotifier interface {
+	NotifyReminder(ctx context.Context, reminder domain.Reminder) error
+}
+
+// ReminderService defines the interface for due date reminders
+type ReminderService interface {
+	// Scan fires the reminders that have become due and returns how many fired
+	Scan(ctx context.Context) (int, error)
+}
+
+// reminderService implements the ReminderService interface
+type reminderService struct {
+	repository ReminderRepository
+	notifier   ReminderNotifier
+	thresholds []domain.ReminderThreshold
+	batchSize  int
+}
+
+// NewReminderService creates a new reminder service firing a reminder at each
+// configured lead before the due date and once the task is overdue
+func NewReminderService(reminders *repo.ReminderRepo, notifier ReminderNotifier, cfg config.ReminderConfig) ReminderService {
+	return &reminderService{
+		repository: reminders,
+		notifier:   notifier,
+		thresholds: reminderThresholds(cfg.Leads),
+		batchSize:  max(cfg.BatchSize, 1),
+	}
+}
+
+// Scan claims due reminders batch by batch and hands them to the notifier.
+// A reminder is claimed, and its event recorded in the outbox, before it is
+// handed over, so it fires at most once even when several replicas scan at the
+// same time; one the notifier fails on is not retried. Subscribers receive
+// reminders through the outbox events, which the relay delivers at least once.
+func (s *reminderService) Scan(ctx context.Context) (int, error) {
+	fired := 0
+	var errs []error
+	for {
+		reminders, ok, err := s.repository.ClaimDue(ctx, s.thresholds, time.Now().UTC(), s.batchSize)
+		if err != nil {
+			return fired, err
+		}
+		if !ok {
+			// Another replica is scanning
+			return fired, nil
+		}
+
+		for _, reminder := range reminders {
+			metrics.RemindersFiredTotal.WithLabelValues(reminder.Threshold).Inc()
+			fired++
+			if err := s.notifier.NotifyReminder(ctx, reminder); err != nil {
+				errs = append(errs, fmt.Errorf("notifying %s reminder of task %s: %w", reminder.Threshold, reminder.TaskID, err))
+			}
+		}
+
+		if len(reminders) < s.batchSize || ctx.Err() != nil {
+			return fired, errors.Join(errs...)
+		}
+	}
+}
+
+// reminderThresholds turns the configured leads into thresholds, longest lead
+// first, followed by the overdue threshold
+func reminderThresholds(leads []time.Duration) []domain.ReminderThreshold {
+	leads = slices.Clone(leads)
+	for i := range leads {
+		leads[i] = leads[i].Truncate(time.Second)
+	}
+	slices.Sort(leads)
+	leads = slices.Compact(leads)
+
+	thresholds := []domain.ReminderThreshold{}
+	for i := len(leads) - 1; i >= 0; i-- {
+		if leads[i] < time.Second {
+			continue
+		}
+		thresholds = append(thresholds, domain.ReminderThreshold{Name: "due_in_" + formatLead(leads[i]), Lead: leads[i]})
+	}
+	return append(thresholds, domain.ReminderThreshold{Name: domain.ThresholdOverdue})
+}
+
+// formatLead renders a lead in its largest whole unit, e.g. "2d", "90m"
+func formatLead(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return fmt.Sprintf("%ds", d/time.Second)
+}
+
+// LogNotifier delivers reminders by writing them to the application log
+type LogNotifier struct {
+	Logger *slog.Logger
+}
+
+// NotifyReminder logs the reminder
+func (n LogNotifier) NotifyReminder(_ context.Context, reminder domain.Reminder) error {
+	n.Logger.Info("Task reminder",
+		"task_id", reminder.TaskID,
+		"title", reminder.Title,
+		"threshold", reminder.Threshold,
+		"due_date", reminder.DueDate,
+		"assignee_id", reminder.AssigneeID,
+	)
+	return nil
+}
@@ -2,6 +2,8 @@ package service
 
 import (
     "context"
+    "encoding/json"
+    "time"
 
     "github.com/google/uuid"
 
@@ -13,10 +15,126 @@ import (
 type TaskRepository interface {
     Create(ctx context.Context, task *domain.Task) error
     List(ctx context.Context, filter domain.TaskFilter) (*domain.TaskList, error)
+    Search(ctx context.Context, filter domain.TaskFilter) (*domain.TaskSearchResults, error)
+    Stats(ctx context.Context, filter domain.TaskFilter, groupBy []domain.StatsDimension, asOf time.Time) (*domain.TaskStats, error)
     Get(ctx context.Context, id uuid.UUID) (*domain.Task, error)
-    Update(ctx context.Context, task *domain.Task) error
-    Patch(ctx context.Context, id uuid.UUID, patch *domain.PatchTaskRequest) (*domain.Task, error)
+    GetIncludingDeleted(ctx context.Context, id uuid.UUID) (*domain.Task, error)
+    GetByKey(ctx context.Context, projectKey string, number int) (*domain.Task, error)
+    GetByKeyIncludingDeleted(ctx context.Context, projectKey string, number int) (*domain.Task, error)
+    Update(ctx context.Context, task *domain.Task, next func(context.Context, *domain.Task, domain.Status) (*domain.Task, error)) error
+    Patch(ctx context.Context, id uuid.UUID, patch *domain.PatchTaskRequest, next func(context.Context, *domain.Task, domain.Status) (*domain.Task, error)) (*domain.Task, error)
+    Delete(ctx context.Context, id uuid.UUID, children domain.ChildPolicy) error
+    Restore(ctx context.Context, id uuid.UUID) error
+    Purge(ctx context.Context, before time.Time, removeContents func(context.Context, []string) error) (int64, error)
+    Archive(ctx context.Context, id uuid.UUID) error
+    Unarchive(ctx context.Context, id uuid.UUID) error
+    ArchiveCompleted(ctx context.Context, before time.Time, projectID *uuid.UUID, limit int) (int, error)
+    Subtree(ctx context.Context, id uuid.UUID) ([]domain.Task, error)
+    IsDescendant(ctx context.Context, ancestor, candidate uuid.UUID) (bool, error)
+    ChildCategoryCounts(ctx context.Context, parentID uuid.UUID) (map[domain.StatusCategory]int, error)
+
+    AddDependency(ctx context.Context, blockerID, blockedID uuid.UUID) (*domain.Dependency, error)
+    RemoveDependency(ctx context.Context, blockerID, blockedID uuid.UUID) error
+    ListBlockers(ctx context.Context, id uuid.UUID) ([]domain.Task, error)
+    ListBlocked(ctx context.Context, id uuid.UUID) ([]domain.Task, error)
+    OpenBlockerIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+
+    Assignments(ctx context.Context, taskID uuid.UUID) ([]domain.Assignment, error)
+
+    History(ctx context.Context, taskID uuid.UUID) ([]domain.TaskHistoryEntry, error)
+    Version(ctx context.Context, taskID uuid.UUID, version int) (*domain.Task, error)
+    Revert(ctx context.Context, task *domain.Task, restoredVersion int, next func(context.Context, *domain.Task, domain.Status) (*domain.Task, error)) error
+}
+
+// StatusRepository defines the access to the configurable status set needed by the service layer.
+type StatusRepository interface {
+    List(ctx context.Context) (domain.StatusSet, error)
+    Get(ctx context.Context, id uuid.UUID) (*domain.StatusDefinition, error)
+    Create(ctx context.Context, status *domain.StatusDefinition) error
+    Update(ctx context.Context, status *domain.StatusDefinition) error
+    Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// LabelRepository defines the label storage needed by the service layer.
+type LabelRepository interface {
+    List(ctx context.Context) ([]domain.Label, error)
+    Get(ctx context.Context, id uuid.UUID) (*domain.Label, error)
+    Create(ctx context.Context, label *domain.Label) error
+    Update(ctx context.Context, label *domain.Label) error
     Delete(ctx context.Context, id uuid.UUID) error
 }
 
+// UserRepository defines the users directory storage needed by the service layer.
+type UserRepository interface {
+    List(ctx context.Context) ([]domain.User, error)
+    Get(ctx context.Context, id uuid.UUID) (*domain.User, error)
+    Create(ctx context.Context, user *domain.User) error
+    Update(ctx context.Context, user *domain.User) error
+    Delete(ctx context.Context, id uuid.UUID) error
+}
 
+// ProjectRepository defines the project storage needed by the service layer.
+type ProjectRepository interface {
+    List(ctx context.Context, includeArchived bool) ([]domain.Project, error)
+    Get(ctx context.Context, id uuid.UUID) (*domain.Project, error)
+    GetByKey(ctx context.Context, key string) (*domain.Project, error)
+    Create(ctx context.Context, project *domain.Project) error
+    Update(ctx context.Context, project *domain.Project) error
+    Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// ViewRepository defines the saved view storage needed by the service layer.
+type ViewRepository interface {
+    List(ctx context.Context, ownerID *uuid.UUID) ([]domain.View, error)
+    Get(ctx context.Context, id uuid.UUID) (*domain.View, error)
+    Create(ctx context.Context, view *domain.View) error
+    Update(ctx context.Context, view *domain.View) error
+    Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// CommentRepository defines the task comment storage needed by the service layer.
+type CommentRepository interface {
+    List(ctx context.Context, taskID uuid.UUID, filter domain.CommentFilter) (*domain.CommentList, error)
+    Get(ctx context.Context, taskID, id uuid.UUID) (*domain.Comment, error)
+    Create(ctx context.Context, comment *domain.Comment) error
+    Update(ctx context.Context, comment *domain.Comment) error
+    Delete(ctx context.Context, taskID, id uuid.UUID) error
+}
+
+// AttachmentRepository defines the attachment metadata storage needed by the service layer.
+type AttachmentRepository interface {
+    List(ctx context.Context, taskID uuid.UUID) ([]domain.Attachment, error)
+    Get(ctx context.Context, taskID, id uuid.UUID) (*domain.Attachment, error)
+    Create(ctx context.Context, attachment *domain.Attachment) error
+    Delete(ctx context.Context, taskID, id uuid.UUID) (*domain.Attachment, error)
+}
+
+// ReminderRepository defines the reminder bookkeeping needed by the service layer.
+type ReminderRepository interface {
+    ClaimDue(ctx context.Context, thresholds []domain.ReminderThreshold, now time.Time, limit int) ([]domain.Reminder, bool, error)
+}
+
+// WebhookRepository defines the webhook and delivery queue storage needed by the service layer.
+type WebhookRepository interface {
+    List(ctx context.Context) ([]domain.Webhook, error)
+    Get(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+    Create(ctx context.Context, webhook *domain.Webhook) error
+    Update(ctx context.Context, webhook *domain.Webhook) error
+    Delete(ctx context.Context, id uuid.UUID) error
+
+    Enqueue(ctx context.Context, eventID uuid.UUID, eventType domain.TaskEventType, payload json.RawMessage) (int64, error)
+    ListDeliveries(ctx context.Context, webhookID uuid.UUID, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error)
+    GetDelivery(ctx context.Context, webhookID, id uuid.UUID) (*domain.WebhookDelivery, error)
+    ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error)
+    RecordAttempt(ctx context.Context, delivery *domain.WebhookDelivery) error
+    Redeliver(ctx context.Context, webhookID, id uuid.UUID) (*domain.WebhookDelivery, error)
+}
+
+// OutboxRepository defines the event outbox access needed by the service layer.
+type OutboxRepository interface {
+    Relay(ctx context.Context, limit int, publish func(context.Context, []domain.TaskEvent) int) (int, error)
+    PurgePublished(ctx context.Context, before time.Time) (int64, error)
+    Event(ctx context.Context, seq int64) (*domain.TaskEvent, error)
+    Recent(ctx context.Context, after int64, limit int) ([]domain.TaskEvent, error)
+    Listen(ctx context.Context, listening func(), notify func(seq int64)) error
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/repo"
+)
+
+// defaultStatusColor is used for statuses created without a color
+const defaultStatusColor = "#9e9e9e"
+
+// StatusService defines the interface for managing the configurable status
+// set: the default statuses and those each project adds to them
+type StatusService interface {
+	List(ctx context.Context, projectID *uuid.UUID) (domain.StatusSet, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.StatusDefinition, error)
+	Create(ctx context.Context, req domain.CreateStatusRequest) (*domain.StatusDefinition, error)
+	Patch(ctx context.Context, id uuid.UUID, req domain.PatchStatusRequest) (*domain.StatusDefinition, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// statusService implements the StatusService interface
+type statusService struct {
+	repository StatusRepository
+}
+
+// NewStatusService creates a new status service
+func NewStatusService(repo *repo.StatusRepo) StatusService {
+	return &statusService{repository: repo}
+}
+
+// List retrieves the statuses available to a project's tasks ordered by
+// position, or the default set when projectID is nil
+func (s *statusService) List(ctx context.Context, projectID *uuid.UUID) (domain.StatusSet, error) {
+	statuses, err := s.repository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return statuses.ForProject(projectID), nil
+}
+
+// Get retrieves a status by ID
+func (s *statusService) Get(ctx context.Context, id uuid.UUID) (*domain.StatusDefinition, error) {
+	status, err := s.repository.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrStatusNotFound) {
+			return nil, ErrStatusNotFound
+		}
+		return nil, err
+	}
+	return status, nil
+}
+
+// Create adds a status to the default set or to a project. Without an
+// explicit position it is placed after every status available to the project.
+func (s *statusService) Create(ctx context.Context, req domain.CreateStatusRequest) (*domain.StatusDefinition, error) {
+	now := time.Now().UTC()
+
+	position := 0
+	if req.Position != nil {
+		position = *req.Position
+	} else {
+		statuses, err := s.List(ctx, req.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if len(statuses) > 0 {
+			position = statuses[len(statuses)-1].Position + 10
+		}
+	}
+
+	color := defaultStatusColor
+	if req.Color != nil {
+		color = *req.Color
+	}
+
+	status := &domain.StatusDefinition{
+		ID:        uuid.New(),
+		ProjectID: req.ProjectID,
+		Name:      req.Name,
+		Category:  req.Category,
+		Position:  position,
+		Color:     color,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repository.Create(ctx, status); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrStatusExists):
+			return nil, ErrStatusExists
+		case errors.Is(err, repo.ErrProjectNotFound):
+			return nil, ErrUnknownProject
+		}
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Patch partially updates a status
+func (s *statusService) Patch(ctx context.Context, id uuid.UUID, req domain.PatchStatusRequest) (*domain.StatusDefinition, error) {
+	status, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Category != nil && *req.Category != status.Category {
+		if err := s.checkKeepsTodo(ctx, status); err != nil {
+			return nil, err
+		}
+		status.Category = *req.Category
+	}
+	if req.Name != nil {
+		status.Name = *req.Name
+	}
+	if req.Position != nil {
+		status.Position = *req.Position
+	}
+	if req.Color != nil {
+		status.Color = *req.Color
+	}
+
+	if err := s.repository.Update(ctx, status); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrStatusNotFound):
+			return nil, ErrStatusNotFound
+		case errors.Is(err, repo.ErrStatusExists):
+			return nil, ErrStatusExists
+		}
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// Delete removes a status that is not used by any task
+func (s *statusService) Delete(ctx context.Context, id uuid.UUID) error {
+	status, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkKeepsTodo(ctx, status); err != nil {
+		return err
+	}
+
+	if err := s.repository.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrStatusNotFound):
+			return ErrStatusNotFound
+		case errors.Is(err, repo.ErrStatusInUse):
+			return ErrStatusInUse
+		}
+		return err
+	}
+
+	return nil
+}
+
+// checkKeepsTodo rejects removing status from the todo category if it is the last
+// default status there, since new tasks default to the first todo status. A
+// project's own statuses come on top of the default set and are never the last.
+func (s *statusService) checkKeepsTodo(ctx context.Context, status *domain.StatusDefinition) error {
+	if status.Category != domain.CategoryTodo || status.ProjectID != nil {
+		return nil
+	}
+
+	statuses, err := s.List(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, other := range statuses {
+		if other.Category == domain.CategoryTodo && other.ID != status.ID {
+			return nil
+		}
+	}
+
+	return ErrLastTodoStatus
+}
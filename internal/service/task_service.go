@@ -3,11 +3,14 @@ package service
 import (
     "context"
     "errors"
+    "fmt"
     "time"
 
     "github.com/google/uuid"
 
+    "task-svc/internal/config"
     "task-svc/internal/domain"
+    "task-svc/internal/platform/storage"
     "task-svc/internal/repo"
 )
 
@@ -15,56 +18,190 @@ import (
 type TaskService interface {
 	Create(ctx context.Context, req domain.CreateTaskRequest) (*domain.Task, error)
 	List(ctx context.Context, filter domain.TaskFilter) (*domain.TaskList, error)
+	Search(ctx context.Context, filter domain.TaskFilter) (*domain.TaskSearchResults, error)
+	Stats(ctx context.Context, filter domain.TaskFilter, groupBy []domain.StatsDimension) (*domain.TaskStats, error)
 	Get(ctx context.Context, id uuid.UUID) (*domain.Task, error)
+	GetIncludingDeleted(ctx context.Context, id uuid.UUID) (*domain.Task, error)
+	GetByKey(ctx context.Context, key string) (*domain.Task, error)
+	GetByKeyIncludingDeleted(ctx context.Context, key string) (*domain.Task, error)
 	Update(ctx context.Context, id uuid.UUID, req domain.UpdateTaskRequest) (*domain.Task, error)
 	Patch(ctx context.Context, id uuid.UUID, req domain.PatchTaskRequest) (*domain.Task, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, children domain.ChildPolicy) error
+	Restore(ctx context.Context, id uuid.UUID) (*domain.Task, error)
+	Trash(ctx context.Context, filter domain.TaskFilter) (*domain.TaskList, error)
+	PurgeTrash(ctx context.Context, retention time.Duration) (int64, error)
+	Archive(ctx context.Context, id uuid.UUID) (*domain.Task, error)
+	Unarchive(ctx context.Context, id uuid.UUID) (*domain.Task, error)
+	ArchiveCompleted(ctx context.Context, req domain.ArchiveTasksRequest) (int, error)
+	AutoArchive(ctx context.Context, after time.Duration) (int, error)
+	ListChildren(ctx context.Context, id uuid.UUID, filter domain.TaskFilter) (*domain.TaskList, error)
+	Tree(ctx context.Context, id uuid.UUID) (*domain.TaskTree, error)
+
+	AddDependency(ctx context.Context, id, blockerID uuid.UUID) (*domain.Dependency, error)
+	RemoveDependency(ctx context.Context, id, blockerID uuid.UUID) error
+	Dependencies(ctx context.Context, id uuid.UUID) (*domain.TaskDependencies, error)
+
+	Assignments(ctx context.Context, id uuid.UUID) ([]domain.Assignment, error)
+
+	History(ctx context.Context, id uuid.UUID) ([]domain.TaskHistoryEntry, error)
+	Version(ctx context.Context, id uuid.UUID, version int) (*domain.Task, error)
+	Revert(ctx context.Context, id uuid.UUID, req domain.RevertTaskRequest) (*domain.Task, error)
+
+	Occurrences(ctx context.Context, id uuid.UUID, n int) ([]time.Time, error)
+
+	Workflow(ctx context.Context, projectID *uuid.UUID) (domain.WorkflowGraph, error)
 }
 
 // taskService implements the TaskService interface
 type taskService struct {
     repository TaskRepository
+    statuses   StatusRepository
+    blobs      storage.BlobStore
+    hierarchy  config.HierarchyConfig
+    workflow   *Workflow
 }
 
-// NewTaskService creates a new task service
-func NewTaskService(repo *repo.TaskRepo) TaskService {
-    // Accept the concrete repo but depend on the TaskRepository interface internally.
-    return &taskService{repository: repo}
+// NewTaskService creates a new task service. blobs holds the attachment
+// contents removed together with purged tasks.
+func NewTaskService(repo *repo.TaskRepo, statuses *repo.StatusRepo, blobs storage.BlobStore, hierarchy config.HierarchyConfig, workflow *Workflow) TaskService {
+    // Accept the concrete repos but depend on the repository interfaces internally.
+    return &taskService{repository: repo, statuses: statuses, blobs: blobs, hierarchy: hierarchy, workflow: workflow}
 }
 
 // Create creates a new task
 func (s *taskService) Create(ctx context.Context, req domain.CreateTaskRequest) (*domain.Task, error) {
 	now := time.Now().UTC()
+
+	// A subtask lives in its parent's project
+	projectID := req.ProjectID
+	if req.ParentID != nil {
+		parent, err := s.repository.Get(ctx, *req.ParentID)
+		if err != nil {
+			if errors.Is(err, repo.ErrNotFound) {
+				return nil, ErrParentNotFound
+			}
+			return nil, err
+		}
+		if projectID == nil {
+			projectID = parent.ProjectID
+		} else if !sameID(projectID, parent.ProjectID) {
+			return nil, ErrProjectMismatch
+		}
+	}
+
+	statuses, err := s.projectStatuses(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
 	
-	// Set default status if not provided
-	status := domain.StatusPending
+	// Set default status if not provided: the first status of the todo category
+	var status domain.Status
 	if req.Status != nil {
 		status = *req.Status
+	} else if def, ok := statuses.First(domain.CategoryTodo); ok {
+		status = def.Name
+	}
+	if _, ok := statuses.Lookup(status); !ok {
+		return nil, ErrUnknownStatus
+	}
+	if !s.workflow.CanCreate(status) {
+		return nil, &TransitionError{To: status, Allowed: s.workflow.Graph(statuses).Initial}
+	}
+	if err := s.checkParentOpen(ctx, req.ParentID, status); err != nil {
+		return nil, err
+	}
+
+	priority, err := priorityOrDefault(req.Priority)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSeverity(req.Severity); err != nil {
+		return nil, err
+	}
+	recurrence, err := normalizeRecurrence(req.Recurrence, req.DueDate)
+	if err != nil {
+		return nil, err
 	}
 	
+	// The reporter defaults to whoever is creating the task
+	reporter := req.ReporterID
+	if reporter == nil {
+		if actor, ok := domain.ActorFromContext(ctx); ok {
+			reporter = &actor
+		}
+	}
+
 	task := &domain.Task{
 		ID:          uuid.New(),
+		ProjectID:   projectID,
 		Title:       req.Title,
 		Description: req.Description,
 		Status:      status,
+		Priority:    priority,
+		Severity:    req.Severity,
 		DueDate:     req.DueDate,
+		ParentID:    req.ParentID,
+		AssigneeID:  req.AssigneeID,
+		ReporterID:  reporter,
+		Labels:      req.Labels,
+		Recurrence:  recurrence,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		Version:     1,
 	}
 	
     if err := s.repository.Create(ctx, task); err != nil {
+		if errors.Is(err, repo.ErrLabelNotFound) {
+			return nil, ErrUnknownLabel
+		}
+		if errors.Is(err, repo.ErrUserNotFound) {
+			return nil, ErrUnknownUser
+		}
+		if errors.Is(err, repo.ErrProjectNotFound) {
+			return nil, ErrUnknownProject
+		}
+		if errors.Is(err, repo.ErrProjectArchived) {
+			return nil, ErrProjectArchived
+		}
 		return nil, err
 	}
+	if task.Labels == nil {
+		task.Labels = []string{}
+	}
+
+	s.rollupParent(ctx, task.ParentID)
 	
 	return task, nil
 }
 
 // List retrieves tasks with pagination and filtering
 func (s *taskService) List(ctx context.Context, filter domain.TaskFilter) (*domain.TaskList, error) {
+	if err := s.isValidStatus(ctx, filter.Status); err != nil {
+		return nil, err
+	}
     return s.repository.List(ctx, filter)
 }
 
+// Search retrieves the tasks matching filter.Search, most relevant first
+func (s *taskService) Search(ctx context.Context, filter domain.TaskFilter) (*domain.TaskSearchResults, error) {
+	if filter.Search == nil {
+		return nil, ErrSearchQueryRequired
+	}
+	if err := s.isValidStatus(ctx, filter.Status); err != nil {
+		return nil, err
+	}
+	return s.repository.Search(ctx, filter)
+}
+
+// Stats counts the tasks matching filter, grouped by the given dimensions,
+// with overdue tasks and due buckets judged as of now
+func (s *taskService) Stats(ctx context.Context, filter domain.TaskFilter, groupBy []domain.StatsDimension) (*domain.TaskStats, error) {
+	if err := s.isValidStatus(ctx, filter.Status); err != nil {
+		return nil, err
+	}
+	return s.repository.Stats(ctx, filter, groupBy, time.Now().UTC())
+}
+
 // Get retrieves a task by ID
 func (s *taskService) Get(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
     t, err := s.repository.Get(ctx, id)
@@ -77,6 +214,46 @@ func (s *taskService) Get(ctx context.Context, id uuid.UUID) (*domain.Task, erro
     return t, nil
 }
 
+// GetIncludingDeleted retrieves a task by ID, even when it is in the trash
+func (s *taskService) GetIncludingDeleted(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	t, err := s.repository.GetIncludingDeleted(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+// GetByKey retrieves a task by its human-friendly key, such as BILL-123
+func (s *taskService) GetByKey(ctx context.Context, key string) (*domain.Task, error) {
+	return s.getByKey(ctx, key, s.repository.GetByKey)
+}
+
+// GetByKeyIncludingDeleted retrieves a task by its human-friendly key, even
+// when it is in the trash
+func (s *taskService) GetByKeyIncludingDeleted(ctx context.Context, key string) (*domain.Task, error) {
+	return s.getByKey(ctx, key, s.repository.GetByKeyIncludingDeleted)
+}
+
+// getByKey parses a task key and looks the task up with lookup
+func (s *taskService) getByKey(ctx context.Context, key string, lookup func(context.Context, string, int) (*domain.Task, error)) (*domain.Task, error) {
+	projectKey, number, ok := domain.ParseTaskKey(key)
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	t, err := lookup(ctx, projectKey, number)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
 // Update fully updates a task
 func (s *taskService) Update(ctx context.Context, id uuid.UUID, req domain.UpdateTaskRequest) (*domain.Task, error) {
 	// First get the existing task
@@ -93,52 +270,623 @@ func (s *taskService) Update(ctx context.Context, id uuid.UUID, req domain.Updat
         return nil, ErrVersionConflict
 	}
 	
+	if err := s.checkParent(ctx, task, req.ParentID); err != nil {
+		return nil, err
+	}
+	if err := s.checkTransition(ctx, task, req.Status); err != nil {
+		return nil, err
+	}
+	if err := s.checkParentOpen(ctx, req.ParentID, req.Status); err != nil {
+		return nil, err
+	}
+
+	priority, err := priorityOrDefault(req.Priority)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSeverity(req.Severity); err != nil {
+		return nil, err
+	}
+	recurrence, err := normalizeRecurrence(req.Recurrence, req.DueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	previousParent := task.ParentID
+
 	// Update the task with new values
 	task.Title = req.Title
 	task.Description = req.Description
 	task.Status = req.Status
+	task.Priority = priority
+	task.Severity = req.Severity
 	task.DueDate = req.DueDate
+	task.ParentID = req.ParentID
+	task.AssigneeID = req.AssigneeID
+	task.Recurrence = recurrence
 	
 	// Save the updated task
-    if err := s.repository.Update(ctx, task); err != nil {
+    if err := s.repository.Update(ctx, task, s.nextOccurrence); err != nil {
         if errors.Is(err, repo.ErrVersionConflict) {
             return nil, ErrVersionConflict
         }
+        if errors.Is(err, repo.ErrUserNotFound) {
+            return nil, ErrUnknownUser
+        }
         if errors.Is(err, repo.ErrNotFound) {
             return nil, ErrNotFound
         }
         return nil, err
 	}
 	
+	s.rollupParent(ctx, task.ParentID)
+	if !sameID(previousParent, task.ParentID) {
+		s.rollupParent(ctx, previousParent)
+	}
+	
 	// Refresh the task to get the updated version and timestamps
-    return s.repository.Get(ctx, id)
+	return s.repository.Get(ctx, id)
 }
 
 // Patch partially updates a task
 func (s *taskService) Patch(ctx context.Context, id uuid.UUID, req domain.PatchTaskRequest) (*domain.Task, error) {
-    t, err := s.repository.Patch(ctx, id, &req)
+	if req.Priority != nil && !req.Priority.Valid() {
+		return nil, ErrUnknownPriority
+	}
+	if err := checkSeverity(req.Severity); err != nil {
+		return nil, err
+	}
+
+	var previousParent *uuid.UUID
+	if req.Status != nil || req.ParentID != nil || req.ClearParent || req.Recurrence != nil {
+		current, err := s.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		previousParent = current.ParentID
+
+		if req.Recurrence != nil && *req.Recurrence != "" {
+			dueDate := current.DueDate
+			if req.DueDate != nil {
+				dueDate = req.DueDate
+			}
+			recurrence, err := normalizeRecurrence(req.Recurrence, dueDate)
+			if err != nil {
+				return nil, err
+			}
+			req.Recurrence = recurrence
+		}
+
+		if err := s.checkParent(ctx, current, req.ParentID); err != nil {
+			return nil, err
+		}
+		if req.Status != nil {
+			if err := s.checkTransition(ctx, current, *req.Status); err != nil {
+				return nil, err
+			}
+		}
+
+		parentID, status := current.ParentID, current.Status
+		if req.ParentID != nil {
+			parentID = req.ParentID
+		}
+		if req.ClearParent {
+			parentID = nil
+		}
+		if req.Status != nil {
+			status = *req.Status
+		}
+		if err := s.checkParentOpen(ctx, parentID, status); err != nil {
+			return nil, err
+		}
+	}
+
+    t, err := s.repository.Patch(ctx, id, &req, s.nextOccurrence)
     if err != nil {
         if errors.Is(err, repo.ErrVersionConflict) {
             return nil, ErrVersionConflict
         }
+        if errors.Is(err, repo.ErrLabelNotFound) {
+            return nil, ErrUnknownLabel
+        }
+        if errors.Is(err, repo.ErrUserNotFound) {
+            return nil, ErrUnknownUser
+        }
         if errors.Is(err, repo.ErrNotFound) {
             return nil, ErrNotFound
         }
         return nil, err
     }
+
+	if req.Status != nil || req.ParentID != nil || req.ClearParent {
+		s.rollupParent(ctx, t.ParentID)
+		if !sameID(previousParent, t.ParentID) {
+			s.rollupParent(ctx, previousParent)
+		}
+	}
+
     return t, nil
 }
 
-// Delete removes a task by ID
-func (s *taskService) Delete(ctx context.Context, id uuid.UUID) error {
-    err := s.repository.Delete(ctx, id)
+// Delete moves a task to the trash, handling its subtasks according to the child policy
+func (s *taskService) Delete(ctx context.Context, id uuid.UUID, children domain.ChildPolicy) error {
+    err := s.repository.Delete(ctx, id, children)
 	if err != nil {
         if errors.Is(err, repo.ErrNotFound) {
-			// It's okay if the task is already gone
-			return nil
+			return ErrNotFound
 		}
 		return err
 	}
 	
 	return nil
 }
+
+// Restore brings a task back from the trash, together with the subtasks
+// deleted with it
+func (s *taskService) Restore(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	if err := s.repository.Restore(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		if errors.Is(err, repo.ErrParentDeleted) {
+			return nil, ErrParentDeleted
+		}
+		return nil, err
+	}
+
+	task, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.rollupParent(ctx, task.ParentID)
+	return task, nil
+}
+
+// Trash lists the tasks in the trash
+func (s *taskService) Trash(ctx context.Context, filter domain.TaskFilter) (*domain.TaskList, error) {
+	filter.Deleted = true
+	return s.List(ctx, filter)
+}
+
+// PurgeTrash permanently removes the tasks that have been in the trash for
+// longer than retention and their subtasks, with their attachment contents,
+// and returns how many were removed
+func (s *taskService) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.repository.Purge(ctx, time.Now().UTC().Add(-retention), s.removeContents)
+}
+
+// removeContents deletes attachment contents from the blob store. Contents
+// that are already gone count as deleted, so a retry after a failure is safe.
+func (s *taskService) removeContents(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			return fmt.Errorf("deleting attachment content: %w", err)
+		}
+	}
+	return nil
+}
+
+// archiveBatchSize bounds the number of tasks archived in one transaction
+const archiveBatchSize = 500
+
+// Archive archives a task, leaving it out of task lists by default
+func (s *taskService) Archive(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	if err := s.repository.Archive(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return s.Get(ctx, id)
+}
+
+// Unarchive brings an archived task back into task lists
+func (s *taskService) Unarchive(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	if err := s.repository.Unarchive(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return s.Get(ctx, id)
+}
+
+// ArchiveCompleted archives every task in a done-category status completed
+// before the requested time, in batches, and returns how many were archived
+func (s *taskService) ArchiveCompleted(ctx context.Context, req domain.ArchiveTasksRequest) (int, error) {
+	total := 0
+	for {
+		n, err := s.repository.ArchiveCompleted(ctx, req.CompletedBefore, req.ProjectID, archiveBatchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < archiveBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// AutoArchive archives the tasks that have been done for longer than after
+func (s *taskService) AutoArchive(ctx context.Context, after time.Duration) (int, error) {
+	return s.ArchiveCompleted(ctx, domain.ArchiveTasksRequest{CompletedBefore: time.Now().UTC().Add(-after)})
+}
+
+// ListChildren retrieves the direct subtasks of a task
+func (s *taskService) ListChildren(ctx context.Context, id uuid.UUID, filter domain.TaskFilter) (*domain.TaskList, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	filter.ParentID = &id
+	return s.List(ctx, filter)
+}
+
+// Tree retrieves a task with all of its subtasks nested below it
+func (s *taskService) Tree(ctx context.Context, id uuid.UUID) (*domain.TaskTree, error) {
+	tasks, err := s.repository.Subtree(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	// The root is found by ID rather than by position, and only its
+	// descendants are grouped under their parents
+	var root domain.Task
+	byParent := make(map[uuid.UUID][]domain.Task)
+	for _, t := range tasks {
+		if t.ID == id {
+			root = t
+			continue
+		}
+		if t.ParentID != nil {
+			byParent[*t.ParentID] = append(byParent[*t.ParentID], t)
+		}
+	}
+
+	var build func(t domain.Task) domain.TaskTree
+	build = func(t domain.Task) domain.TaskTree {
+		node := domain.TaskTree{Task: t, Children: []domain.TaskTree{}}
+		for _, child := range byParent[t.ID] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	tree := build(root)
+	return &tree, nil
+}
+
+// Assignments retrieves the reassignment history of a task, oldest first
+func (s *taskService) Assignments(ctx context.Context, id uuid.UUID) ([]domain.Assignment, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repository.Assignments(ctx, id)
+}
+
+// History retrieves every recorded version of a task with the fields it
+// changed, oldest first
+func (s *taskService) History(ctx context.Context, id uuid.UUID) ([]domain.TaskHistoryEntry, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repository.History(ctx, id)
+}
+
+// Version retrieves a task as it was at the given version
+func (s *taskService) Version(ctx context.Context, id uuid.UUID, version int) (*domain.Task, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	task, err := s.repository.Version(ctx, id, version)
+	if err != nil {
+		if errors.Is(err, repo.ErrVersionNotFound) {
+			return nil, ErrVersionNotFound
+		}
+		return nil, err
+	}
+	return task, nil
+}
+
+// Revert restores the fields of an earlier version of a task as a new
+// version. The restored values go through the same checks as an update, so a
+// revert may be refused when, for example, the old parent has been deleted or
+// the workflow does not allow going back to the old status.
+func (s *taskService) Revert(ctx context.Context, id uuid.UUID, req domain.RevertTaskRequest) (*domain.Task, error) {
+	task, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task.Version != req.Version {
+		return nil, ErrVersionConflict
+	}
+
+	target, err := s.Version(ctx, id, req.ToVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkParent(ctx, task, target.ParentID); err != nil {
+		return nil, err
+	}
+	if err := s.checkTransition(ctx, task, target.Status); err != nil {
+		return nil, err
+	}
+	if err := s.checkParentOpen(ctx, target.ParentID, target.Status); err != nil {
+		return nil, err
+	}
+
+	previousParent := task.ParentID
+
+	task.Title = target.Title
+	task.Description = target.Description
+	task.Status = target.Status
+	task.Priority = target.Priority
+	task.Severity = target.Severity
+	task.DueDate = target.DueDate
+	task.ParentID = target.ParentID
+	task.AssigneeID = target.AssigneeID
+	task.Labels = target.Labels
+	task.Recurrence = target.Recurrence
+
+	if err := s.repository.Revert(ctx, task, req.ToVersion, s.nextOccurrence); err != nil {
+		if errors.Is(err, repo.ErrVersionConflict) {
+			return nil, ErrVersionConflict
+		}
+		if errors.Is(err, repo.ErrUserNotFound) {
+			return nil, ErrUnknownUser
+		}
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	s.rollupParent(ctx, task.ParentID)
+	if !sameID(previousParent, task.ParentID) {
+		s.rollupParent(ctx, previousParent)
+	}
+
+	return s.Get(ctx, id)
+}
+
+// Workflow returns the status workflow enforced by the service over the
+// statuses available to a project's tasks, or over the default set when
+// projectID is nil
+func (s *taskService) Workflow(ctx context.Context, projectID *uuid.UUID) (domain.WorkflowGraph, error) {
+	statuses, err := s.projectStatuses(ctx, projectID)
+	if err != nil {
+		return domain.WorkflowGraph{}, err
+	}
+	return s.workflow.Graph(statuses), nil
+}
+
+// projectStatuses returns the live statuses available to a project's tasks
+func (s *taskService) projectStatuses(ctx context.Context, projectID *uuid.UUID) (domain.StatusSet, error) {
+	statuses, err := s.statuses.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return statuses.ForProject(projectID), nil
+}
+
+// isValidStatus checks an optional status against the live status set
+func (s *taskService) isValidStatus(ctx context.Context, status *domain.Status) error {
+	if status == nil {
+		return nil
+	}
+
+	statuses, err := s.statuses.List(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := statuses.Lookup(*status); !ok {
+		return ErrUnknownStatus
+	}
+
+	return nil
+}
+
+// checkParent verifies that parentID can become the parent of task. The parent
+// must belong to the same project.
+func (s *taskService) checkParent(ctx context.Context, task *domain.Task, parentID *uuid.UUID) error {
+	if parentID == nil {
+		return nil
+	}
+	if *parentID == task.ID {
+		return ErrParentCycle
+	}
+
+	parent, err := s.repository.Get(ctx, *parentID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrParentNotFound
+		}
+		return err
+	}
+	if !sameID(parent.ProjectID, task.ProjectID) {
+		return ErrProjectMismatch
+	}
+
+	// The new parent must not sit below the task, otherwise the hierarchy loops
+	cycle, err := s.repository.IsDescendant(ctx, task.ID, *parentID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return ErrParentCycle
+	}
+
+	return nil
+}
+
+// checkParentOpen refuses to leave a task in status under a parent in a done
+// status: a done task has no open subtasks, whether it was closed before or
+// after they came
+func (s *taskService) checkParentOpen(ctx context.Context, parentID *uuid.UUID, status domain.Status) error {
+	if parentID == nil {
+		return nil
+	}
+
+	parent, err := s.repository.Get(ctx, *parentID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return ErrParentNotFound
+		}
+		return err
+	}
+	statuses, err := s.projectStatuses(ctx, parent.ProjectID)
+	if err != nil {
+		return err
+	}
+	if statuses.Category(parent.Status) == domain.CategoryDone && statuses.Category(status) != domain.CategoryDone {
+		return ErrParentDone
+	}
+
+	return nil
+}
+
+// checkTransition runs the rules that guard moving a task into a new status,
+// which must be available to the task's project. Re-saving a task with its
+// current status is always allowed.
+func (s *taskService) checkTransition(ctx context.Context, task *domain.Task, next domain.Status) error {
+	if next == task.Status {
+		return nil
+	}
+
+	statuses, err := s.projectStatuses(ctx, task.ProjectID)
+	if err != nil {
+		return err
+	}
+	def, ok := statuses.Lookup(next)
+	if !ok {
+		return ErrUnknownStatus
+	}
+
+	if !s.workflow.CanTransition(task.Status, next) {
+		return &TransitionError{From: task.Status, To: next, Allowed: s.workflow.Allowed(task.Status, statuses)}
+	}
+
+	if def.Category == domain.CategoryDone {
+		counts, err := s.repository.ChildCategoryCounts(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		if counts[domain.CategoryTodo]+counts[domain.CategoryDoing] > 0 {
+			return ErrOpenSubtasks
+		}
+	}
+
+	if def.Category == domain.CategoryDoing || def.Category == domain.CategoryDone {
+		blockers, err := s.repository.OpenBlockerIDs(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		if len(blockers) > 0 {
+			return &BlockedError{BlockerIDs: blockers}
+		}
+	}
+
+	return nil
+}
+
+// rollupParent advances ancestors to reflect the progress of their subtasks when
+// status rollup is enabled. A recurring ancestor it completes gets its next
+// occurrence like one completed by hand. It is best-effort: the child change is
+// already saved, so a failure here (for example a concurrent edit of the
+// parent) is not reported.
+func (s *taskService) rollupParent(ctx context.Context, parentID *uuid.UUID) {
+	if !s.hierarchy.RollupStatus {
+		return
+	}
+
+	all, err := s.statuses.List(ctx)
+	if err != nil {
+		return
+	}
+
+	for parentID != nil {
+		parent, err := s.repository.Get(ctx, *parentID)
+		if err != nil {
+			return
+		}
+
+		counts, err := s.repository.ChildCategoryCounts(ctx, parent.ID)
+		if err != nil {
+			return
+		}
+
+		next := rolledUpStatus(parent.Status, all.ForProject(parent.ProjectID), counts)
+		if next == parent.Status {
+			return
+		}
+		if err := s.checkTransition(ctx, parent, next); err != nil {
+			return
+		}
+
+		parent.Status = next
+		if err := s.repository.Update(ctx, parent, s.nextOccurrence); err != nil {
+			return
+		}
+		parentID = parent.ParentID
+	}
+}
+
+// rolledUpStatus derives a parent's status from the category counts of its children.
+// A parent still in todo starts once any child has started, and an unfinished
+// parent finishes once every child is done. The first status of the target
+// category is used.
+func rolledUpStatus(current domain.Status, statuses domain.StatusSet, counts map[domain.StatusCategory]int) domain.Status {
+	category := statuses.Category(current)
+	open := counts[domain.CategoryTodo] + counts[domain.CategoryDoing]
+
+	target := category
+	switch {
+	case open == 0 && counts[domain.CategoryDone] > 0 && category != domain.CategoryDone:
+		target = domain.CategoryDone
+	case category == domain.CategoryTodo && counts[domain.CategoryDoing]+counts[domain.CategoryDone] > 0:
+		target = domain.CategoryDoing
+	}
+	if target == category {
+		return current
+	}
+
+	if def, ok := statuses.First(target); ok {
+		return def.Name
+	}
+	return current
+}
+
+// sameID reports whether two optional IDs refer to the same entity
+func sameID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// priorityOrDefault checks a requested priority, falling back to the default when none was given
+func priorityOrDefault(p *domain.Priority) (domain.Priority, error) {
+	if p == nil {
+		return domain.DefaultPriority, nil
+	}
+	if !p.Valid() {
+		return "", ErrUnknownPriority
+	}
+	return *p, nil
+}
+
+// checkSeverity checks an optional severity
+func checkSeverity(s *domain.Severity) error {
+	if s != nil && !s.Valid() {
+		return ErrUnknownSeverity
+	}
+	return nil
+}
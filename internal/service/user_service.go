@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/repo"
+)
+
+// UserService defines the interface for users directory operations
+type UserService interface {
+	List(ctx context.Context) ([]domain.User, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	Create(ctx context.Context, req domain.CreateUserRequest) (*domain.User, error)
+	Patch(ctx context.Context, id uuid.UUID, req domain.PatchUserRequest) (*domain.User, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// userService implements the UserService interface
+type userService struct {
+	repository UserRepository
+}
+
+// NewUserService creates a new user service
+func NewUserService(repo *repo.UserRepo) UserService {
+	return &userService{repository: repo}
+}
+
+// List retrieves every user
+func (s *userService) List(ctx context.Context) ([]domain.User, error) {
+	return s.repository.List(ctx)
+}
+
+// Get retrieves a user by ID
+func (s *userService) Get(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, err := s.repository.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrUserNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// Create adds a user to the directory
+func (s *userService) Create(ctx context.Context, req domain.CreateUserRequest) (*domain.User, error) {
+	now := time.Now().UTC()
+
+	user := &domain.User{
+		ID:        uuid.New(),
+		Email:     req.Email,
+		Name:      req.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repository.Create(ctx, user); err != nil {
+		if errors.Is(err, repo.ErrUserExists) {
+			return nil, ErrUserExists
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Patch partially updates a user
+func (s *userService) Patch(ctx context.Context, id uuid.UUID, req domain.PatchUserRequest) (*domain.User, error) {
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Email != nil {
+		user.Email = *req.Email
+	}
+	if req.Name != nil {
+		user.Name = *req.Name
+	}
+
+	if err := s.repository.Update(ctx, user); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrUserNotFound):
+			return nil, ErrUserNotFound
+		case errors.Is(err, repo.ErrUserExists):
+			return nil, ErrUserExists
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Delete removes a user; their tasks become unassigned
+func (s *userService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.repository.Delete(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrUserNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"task-svc/internal/domain"
+	"task-svc/internal/repo"
+)
+
+// ViewService defines the interface for saved view operations. Views are
+// owned by the acting user who saves them; only the owner may change them.
+type ViewService interface {
+	List(ctx context.Context) ([]domain.View, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.View, error)
+	Create(ctx context.Context, req domain.CreateViewRequest) (*domain.View, error)
+	Patch(ctx context.Context, id uuid.UUID, req domain.PatchViewRequest) (*domain.View, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// viewService implements the ViewService interface
+type viewService struct {
+	repository ViewRepository
+}
+
+// NewViewService creates a new view service
+func NewViewService(repo *repo.ViewRepo) ViewService {
+	return &viewService{repository: repo}
+}
+
+// List retrieves the acting user's views and every shared view
+func (s *viewService) List(ctx context.Context) ([]domain.View, error) {
+	return s.repository.List(ctx, actingUser(ctx))
+}
+
+// Get retrieves a view visible to the acting user. Other users' private
+// views are reported as not found.
+func (s *viewService) Get(ctx context.Context, id uuid.UUID) (*domain.View, error) {
+	view, err := s.repository.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrViewNotFound) {
+			return nil, ErrViewNotFound
+		}
+		return nil, err
+	}
+	if !view.VisibleTo(actingUser(ctx)) {
+		return nil, ErrViewNotFound
+	}
+	return view, nil
+}
+
+// Create saves a view owned by the acting user
+func (s *viewService) Create(ctx context.Context, req domain.CreateViewRequest) (*domain.View, error) {
+	owner, ok := domain.ActorFromContext(ctx)
+	if !ok {
+		return nil, ErrActorRequired
+	}
+	now := time.Now().UTC()
+
+	view := &domain.View{
+		ID:        uuid.New(),
+		OwnerID:   owner,
+		Name:      req.Name,
+		Filter:    req.Filter,
+		Sort:      req.Sort,
+		Columns:   req.Columns,
+		Shared:    req.Shared,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if view.Filter == nil {
+		view.Filter = map[string]string{}
+	}
+	if view.Columns == nil {
+		view.Columns = []string{}
+	}
+
+	if err := s.repository.Create(ctx, view); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrViewExists):
+			return nil, ErrViewExists
+		case errors.Is(err, repo.ErrUserNotFound):
+			return nil, ErrUnknownUser
+		}
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// Patch partially updates a view of the acting user
+func (s *viewService) Patch(ctx context.Context, id uuid.UUID, req domain.PatchViewRequest) (*domain.View, error) {
+	view, err := s.owned(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		view.Name = *req.Name
+	}
+	if req.Filter != nil {
+		view.Filter = req.Filter
+	}
+	if req.Sort != nil {
+		view.Sort = *req.Sort
+	}
+	if req.Columns != nil {
+		view.Columns = req.Columns
+	}
+	if req.Shared != nil {
+		view.Shared = *req.Shared
+	}
+
+	if err := s.repository.Update(ctx, view); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrViewNotFound):
+			return nil, ErrViewNotFound
+		case errors.Is(err, repo.ErrViewExists):
+			return nil, ErrViewExists
+		}
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// Delete removes a view of the acting user
+func (s *viewService) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.owned(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repository.Delete(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrViewNotFound) {
+			return ErrViewNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// owned retrieves a view the acting user may change: one they own. Shared
+// views of other users are visible but not theirs to change.
+func (s *viewService) owned(ctx context.Context, id uuid.UUID) (*domain.View, error) {
+	user, ok := domain.ActorFromContext(ctx)
+	if !ok {
+		return nil, ErrActorRequired
+	}
+	view, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if view.OwnerID != user {
+		return nil, ErrViewForbidden
+	}
+	return view, nil
+}
+
+// actingUser returns the acting user, or nil when the caller is anonymous
+func actingUser(ctx context.Context) *uuid.UUID {
+	if id, ok := domain.ActorFromContext(ctx); ok {
+		return &id
+	}
+	return nil
+}
@@ -0,0 +1,413 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"task-svc/internal/config"
+	"task-svc/internal/domain"
+	"task-svc/internal/platform/httpclient"
+	"task-svc/internal/repo"
+)
+
+// Webhook delivery headers
+const (
+	HeaderWebhookID        = "X-Webhook-ID"
+	HeaderWebhookDelivery  = "X-Webhook-Delivery"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+)
+
+// deliveryLeaseMargin is how long a claimed delivery stays hidden from other
+// workers beyond the request timeout, before it is considered abandoned
+const deliveryLeaseMargin = 30 * time.Second
+
+// maxDeliveryError bounds the error message stored with a failed attempt
+const maxDeliveryError = 1000
+
+// WebhookService defines the interface for webhook subscriptions and deliveries
+type WebhookService interface {
+	List(ctx context.Context) ([]domain.Webhook, error)
+	Get(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+	Create(ctx context.Context, req domain.CreateWebhookRequest) (*domain.Webhook, error)
+	Patch(ctx context.Context, id uuid.UUID, req domain.PatchWebhookRequest) (*domain.Webhook, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	Deliveries(ctx context.Context, webhookID uuid.UUID, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error)
+	Redeliver(ctx context.Context, webhookID, id uuid.UUID) (*domain.WebhookDelivery, error)
+
+	EventSink
+
+	// DeliverDue attempts the queued deliveries that are due and returns how
+	// many were attempted
+	DeliverDue(ctx context.Context) (int, error)
+}
+
+// webhookService implements the WebhookService interface
+type webhookService struct {
+	repository WebhookRepository
+	statuses   StatusRepository
+	client     *httpclient.Client
+	config     config.WebhookConfig
+	logger     *slog.Logger
+}
+
+// NewWebhookService creates a new webhook service sending deliveries through client
+func NewWebhookService(webhooks *repo.WebhookRepo, statuses *repo.StatusRepo, client *httpclient.Client, cfg config.WebhookConfig, logger *slog.Logger) WebhookService {
+	return &webhookService{
+		repository: webhooks,
+		statuses:   statuses,
+		client:     client,
+		config:     cfg,
+		logger:     logger,
+	}
+}
+
+// List retrieves every webhook
+func (s *webhookService) List(ctx context.Context) ([]domain.Webhook, error) {
+	return s.repository.List(ctx)
+}
+
+// Get retrieves a webhook by ID
+func (s *webhookService) Get(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	webhook, err := s.repository.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrWebhookNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// Create adds a webhook, generating its secret when none is given
+func (s *webhookService) Create(ctx context.Context, req domain.CreateWebhookRequest) (*domain.Webhook, error) {
+	if err := checkWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+	events, err := checkEventTypes(req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := ""
+	if req.Secret != nil {
+		secret = *req.Secret
+	} else if secret, err = generateSecret(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	webhook := &domain.Webhook{
+		ID:        uuid.New(),
+		URL:       req.URL,
+		Secret:    secret,
+		Events:    events,
+		Active:    req.Active == nil || *req.Active,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repository.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// Patch partially updates a webhook
+func (s *webhookService) Patch(ctx context.Context, id uuid.UUID, req domain.PatchWebhookRequest) (*domain.Webhook, error) {
+	webhook, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := checkWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *req.URL
+	}
+	if req.Secret != nil {
+		webhook.Secret = *req.Secret
+	}
+	if req.Events != nil {
+		events, err := checkEventTypes(*req.Events)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Events = events
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+
+	if err := s.repository.Update(ctx, webhook); err != nil {
+		if errors.Is(err, repo.ErrWebhookNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// Delete removes a webhook and drops its queued deliveries
+func (s *webhookService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.repository.Delete(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrWebhookNotFound) {
+			return ErrWebhookNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Deliveries retrieves the deliveries of a webhook, newest first
+func (s *webhookService) Deliveries(ctx context.Context, webhookID uuid.UUID, filter domain.DeliveryFilter) ([]domain.WebhookDelivery, error) {
+	if _, err := s.Get(ctx, webhookID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListDeliveries(ctx, webhookID, filter)
+}
+
+// Redeliver queues a delivery again, whatever its state, with a fresh set of
+// attempts. It is sent on the next run of the delivery worker.
+func (s *webhookService) Redeliver(ctx context.Context, webhookID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	if _, err := s.Get(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.repository.Redeliver(ctx, webhookID, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrDeliveryNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Name identifies the webhooks as an event sink
+func (s *webhookService) Name() string {
+	return "webhooks"
+}
+
+// Publish queues an event for every webhook subscribed to it. A status change
+// from outside the done category into it is queued as task.completed as well,
+// under an ID derived from the event's so that it stays the same when the
+// event is relayed again.
+func (s *webhookService) Publish(ctx context.Context, event domain.TaskEvent) error {
+	if err := s.enqueue(ctx, event); err != nil {
+		return err
+	}
+	if event.Type != domain.EventTaskStatusChanged {
+		return nil
+	}
+
+	all, err := s.statuses.List(ctx)
+	if err != nil {
+		return err
+	}
+	statuses := all.ForProject(event.Task.ProjectID)
+	if statuses.Category(event.Task.Status) != domain.CategoryDone ||
+		(event.PreviousStatus != nil && statuses.Category(*event.PreviousStatus) == domain.CategoryDone) {
+		return nil
+	}
+
+	completed := event
+	completed.ID = uuid.NewSHA1(event.ID, []byte(domain.EventTaskCompleted))
+	completed.Type = domain.EventTaskCompleted
+	return s.enqueue(ctx, completed)
+}
+
+// enqueue queues a delivery of event for every webhook subscribed to it
+func (s *webhookService) enqueue(ctx context.Context, event domain.TaskEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding %s event: %w", event.Type, err)
+	}
+	_, err = s.repository.Enqueue(ctx, event.ID, event.Type, payload)
+	return err
+}
+
+// DeliverDue claims due deliveries batch by batch and sends each batch
+// concurrently until the queue has nothing more that is due
+func (s *webhookService) DeliverDue(ctx context.Context) (int, error) {
+	attempted := 0
+	for ctx.Err() == nil {
+		now := time.Now().UTC()
+		deliveries, err := s.repository.ClaimDeliveries(ctx, now, now.Add(s.config.Timeout+deliveryLeaseMargin), s.config.BatchSize)
+		if err != nil {
+			return attempted, err
+		}
+		if len(deliveries) == 0 {
+			break
+		}
+
+		webhooks := make(map[uuid.UUID]*domain.Webhook)
+		var wg sync.WaitGroup
+		for i := range deliveries {
+			delivery := &deliveries[i]
+			webhook, ok := webhooks[delivery.WebhookID]
+			if !ok {
+				webhook, err = s.repository.Get(ctx, delivery.WebhookID)
+				if err != nil && !errors.Is(err, repo.ErrWebhookNotFound) {
+					wg.Wait()
+					return attempted, err
+				}
+				webhooks[delivery.WebhookID] = webhook
+			}
+			if webhook == nil {
+				// Deleted since the claim, together with its deliveries
+				continue
+			}
+
+			attempted++
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.attempt(ctx, webhook, delivery)
+			}()
+		}
+		wg.Wait()
+
+		if len(deliveries) < s.config.BatchSize {
+			break
+		}
+	}
+
+	return attempted, nil
+}
+
+// attempt sends a delivery and records the outcome: delivered on a 2xx
+// response, otherwise retried with exponential backoff until the attempts run
+// out and the delivery is dead
+func (s *webhookService) attempt(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) {
+	timestamp := time.Now().Unix()
+	resp, err := s.client.Do(ctx, http.MethodPost, webhook.URL, &httpclient.RequestOptions{
+		BodyReader:  bytes.NewReader(delivery.Payload),
+		ContentType: "application/json",
+		Headers: map[string]string{
+			HeaderWebhookID:        webhook.ID.String(),
+			HeaderWebhookDelivery:  delivery.ID.String(),
+			HeaderWebhookEvent:     string(delivery.EventType),
+			HeaderWebhookTimestamp: strconv.FormatInt(timestamp, 10),
+			HeaderWebhookSignature: Signature(webhook.Secret, timestamp, delivery.Payload),
+		},
+	}, nil)
+	if ctx.Err() != nil {
+		// Shutting down; the lease runs out and the delivery is retried
+		return
+	}
+
+	now := time.Now().UTC()
+	delivery.LastStatusCode = nil
+	if resp != nil {
+		code := resp.StatusCode
+		delivery.LastStatusCode = &code
+	}
+
+	switch {
+	case err == nil:
+		delivery.Status = domain.DeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = nil
+	case delivery.Attempts >= s.config.MaxAttempts:
+		delivery.Status = domain.DeliveryDead
+		delivery.LastError = deliveryError(err)
+	default:
+		delivery.Status = domain.DeliveryPending
+		delivery.NextAttemptAt = now.Add(s.backoff(delivery.Attempts))
+		delivery.LastError = deliveryError(err)
+	}
+
+	if err := s.repository.RecordAttempt(ctx, delivery); err != nil {
+		s.logger.Error("Failed to record webhook delivery attempt", "delivery_id", delivery.ID, "error", err)
+		return
+	}
+	if delivery.Status == domain.DeliveryDead {
+		s.logger.Warn("Webhook delivery is dead", "webhook_id", webhook.ID, "delivery_id", delivery.ID, "attempts", delivery.Attempts)
+	}
+}
+
+// backoff returns the wait before the attempt that follows the given number
+// of failed attempts: BackoffBase doubled per earlier failure, up to BackoffMax
+func (s *webhookService) backoff(attempts int) time.Duration {
+	wait := s.config.BackoffBase
+	for i := 1; i < attempts && wait < s.config.BackoffMax; i++ {
+		wait *= 2
+	}
+	return min(wait, s.config.BackoffMax)
+}
+
+// Signature computes the X-Webhook-Signature header of a payload sent at the
+// given Unix timestamp: the hex HMAC-SHA256, keyed with the webhook secret,
+// of the timestamp, a dot and the raw body
+func Signature(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// checkWebhookURL accepts absolute http and https URLs
+func checkWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+// checkEventTypes validates an event filter and drops duplicates
+func checkEventTypes(events []domain.TaskEventType) ([]domain.TaskEventType, error) {
+	checked := []domain.TaskEventType{}
+	for _, e := range events {
+		if !e.Valid() {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, e)
+		}
+		if !slices.Contains(checked, e) {
+			checked = append(checked, e)
+		}
+	}
+	return checked, nil
+}
+
+// generateSecret returns a random signing secret
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// deliveryError describes a failed attempt for storage
+func deliveryError(err error) *string {
+	msg := err.Error()
+	if len(msg) > maxDeliveryError {
+		msg = msg[:maxDeliveryError]
+	}
+	// Response bodies end up in the message; keep it storable as text
+	msg = strings.ReplaceAll(strings.ToValidUTF8(msg, ""), "\x00", "")
+	return &msg
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"task-svc/internal/config"
+	"task-svc/internal/domain"
+)
+
+// Workflow is the state machine that decides which status changes are allowed.
+// It only constrains the statuses named in its configuration: statuses created
+// later through the statuses API can be entered and left freely until the
+// workflow configuration mentions them.
+type Workflow struct {
+	initial     []domain.Status
+	transitions map[domain.Status][]domain.Status
+	known       map[domain.Status]bool
+}
+
+// NewWorkflow builds a workflow from configuration
+func NewWorkflow(cfg config.WorkflowConfig) (*Workflow, error) {
+	wf := &Workflow{
+		transitions: make(map[domain.Status][]domain.Status),
+		known:       make(map[domain.Status]bool),
+	}
+
+	for _, name := range cfg.Initial {
+		s := domain.Status(strings.TrimSpace(name))
+		if s == "" {
+			return nil, fmt.Errorf("workflow: empty initial status")
+		}
+		wf.initial = append(wf.initial, s)
+		wf.known[s] = true
+	}
+
+	for from, targets := range cfg.Transitions {
+		fromStatus := domain.Status(strings.TrimSpace(from))
+		if fromStatus == "" {
+			return nil, fmt.Errorf("workflow: empty status in transitions")
+		}
+		wf.known[fromStatus] = true
+		for _, to := range strings.Split(targets, "|") {
+			toStatus := domain.Status(strings.TrimSpace(to))
+			if toStatus == "" {
+				continue
+			}
+			wf.transitions[fromStatus] = append(wf.transitions[fromStatus], toStatus)
+			wf.known[toStatus] = true
+		}
+	}
+
+	return wf, nil
+}
+
+// CanCreate reports whether a task may be created with the given status
+func (w *Workflow) CanCreate(status domain.Status) bool {
+	return !w.known[status] || contains(w.initial, status)
+}
+
+// CanTransition reports whether a task may move from one status to another.
+// Staying in the same status is always allowed, and a status the workflow
+// mentions without outgoing transitions is terminal.
+func (w *Workflow) CanTransition(from, to domain.Status) bool {
+	if from == to || !w.known[from] || !w.known[to] {
+		return true
+	}
+	return contains(w.transitions[from], to)
+}
+
+// Allowed returns the statuses of the set reachable from the given status in one step
+func (w *Workflow) Allowed(from domain.Status, statuses domain.StatusSet) []domain.Status {
+	allowed := []domain.Status{}
+	for _, def := range statuses {
+		if def.Name != from && w.CanTransition(from, def.Name) {
+			allowed = append(allowed, def.Name)
+		}
+	}
+	return allowed
+}
+
+// Graph returns the workflow over the given status set for clients
+func (w *Workflow) Graph(statuses domain.StatusSet) domain.WorkflowGraph {
+	graph := domain.WorkflowGraph{
+		Statuses:    []domain.Status{},
+		Initial:     []domain.Status{},
+		Transitions: make(map[domain.Status][]domain.Status, len(statuses)),
+	}
+	for _, def := range statuses {
+		graph.Statuses = append(graph.Statuses, def.Name)
+		if w.CanCreate(def.Name) {
+			graph.Initial = append(graph.Initial, def.Name)
+		}
+		graph.Transitions[def.Name] = w.Allowed(def.Name, statuses)
+	}
+	return graph
+}
+
+// contains reports whether status is in the list
+func contains(list []domain.Status, status domain.Status) bool {
+	for _, s := range list {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
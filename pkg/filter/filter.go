@@ -0,0 +1,431 @@
+// Package filter parses filter expressions such as
+//
+//	status in (Pending, InProgress) and due_date < now+7d and title ~ "deploy"
+//
+// into a syntax tree, checking every field and operator against a schema so
+// that the tree can be compiled into a query without further validation.
+//
+// Conditions compare a field with a value (=, !=, <, <=, >, >=), match text
+// (~ contains, !~ does not contain, both case-insensitive), test membership
+// (in, not in) or test for absence (is null, is not null). They combine with
+// and, or, not and parentheses; and binds tighter than or. Values are bare
+// words or double-quoted strings with \" and \\ escapes. Times are now,
+// now±N followed by s, m, h, d or w, a date such as 2024-05-01 (midnight UTC)
+// or an RFC 3339 timestamp. Keywords are case-insensitive.
+package filter
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limits on the size of an expression
+const (
+	MaxLength     = 2000
+	MaxConditions = 50
+	MaxDepth      = 20
+	MaxListValues = 100
+)
+
+// Type is the type of a field, which decides the operators it takes and how
+// its values are read
+type Type int
+
+// Field types
+const (
+	// String fields hold one of a set of names: =, !=, in, not in
+	String Type = iota
+	// Text fields hold free text: =, !=, ~, !~
+	Text
+	// Time fields hold instants: <, <=, >, >=
+	Time
+	// UUID fields hold IDs: =, !=, in, not in
+	UUID
+)
+
+// Field describes a field that expressions may refer to. Nullable fields also
+// take is null and is not null. Values, when set, lists the values a String
+// field accepts; they match case-insensitively.
+type Field struct {
+	Type     Type
+	Nullable bool
+	Values   []string
+}
+
+// Schema lists the fields expressions may refer to, by name
+type Schema map[string]Field
+
+// Op is a comparison operator
+type Op string
+
+// Operators
+const (
+	OpEq        Op = "="
+	OpNe        Op = "!="
+	OpLt        Op = "<"
+	OpLe        Op = "<="
+	OpGt        Op = ">"
+	OpGe        Op = ">="
+	OpMatch     Op = "~"
+	OpNotMatch  Op = "!~"
+	OpIn        Op = "in"
+	OpNotIn     Op = "not in"
+	OpIsNull    Op = "is null"
+	OpIsNotNull Op = "is not null"
+)
+
+// typeOps lists the operators each field type takes, apart from the null tests
+var typeOps = map[Type][]Op{
+	String: {OpEq, OpNe, OpIn, OpNotIn},
+	Text:   {OpEq, OpNe, OpMatch, OpNotMatch},
+	Time:   {OpLt, OpLe, OpGt, OpGe},
+	UUID:   {OpEq, OpNe, OpIn, OpNotIn},
+}
+
+// Node is a node of a parsed expression: And, Or, Not or Condition
+type Node interface {
+	node()
+}
+
+// And matches when both operands match
+type And struct {
+	Left, Right Node
+}
+
+// Or matches when either operand matches
+type Or struct {
+	Left, Right Node
+}
+
+// Not matches when its operand does not
+type Not struct {
+	X Node
+}
+
+// Condition tests one field. Values holds one value for comparisons, one or
+// more for in and not in, and none for the null tests. Each value is a string
+// for String and Text fields, a time.Time for Time fields and a uuid.UUID for
+// UUID fields.
+type Condition struct {
+	Field  string
+	Op     Op
+	Values []any
+}
+
+func (And) node()       {}
+func (Or) node()        {}
+func (Not) node()       {}
+func (Condition) node() {}
+
+// Error reports an invalid expression together with the offending token and
+// its position, counted in characters from 1
+type Error struct {
+	Pos     int
+	Token   string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("position %d: %s", e.Pos, e.Message)
+	}
+	return fmt.Sprintf("position %d (%q): %s", e.Pos, e.Token, e.Message)
+}
+
+// Parse parses an expression over the fields of schema. Relative times are
+// resolved against now. Every error is an *Error.
+func Parse(input string, schema Schema, now time.Time) (Node, error) {
+	if n := len([]rune(input)); n > MaxLength {
+		return nil, &Error{Pos: MaxLength + 1, Message: fmt.Sprintf("filter is longer than %d characters", MaxLength)}
+	}
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, schema: schema, now: now}
+	if p.peek().kind == tokEOF {
+		return nil, p.errorAt(p.peek(), "filter is empty")
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorAt(tok, "expected and, or or the end of the filter")
+	}
+
+	return node, nil
+}
+
+// parser is a recursive descent parser over the tokens of an expression
+type parser struct {
+	tokens     []token
+	pos        int
+	schema     Schema
+	now        time.Time
+	depth      int
+	conditions int
+}
+
+// peek returns the next token without consuming it
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next consumes and returns the next token
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// errorAt reports an error at tok
+func (p *parser) errorAt(tok token, format string, args ...any) *Error {
+	return &Error{Pos: tok.pos, Token: tok.text, Message: fmt.Sprintf(format, args...)}
+}
+
+// parseOr parses operands joined by or
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses operands joined by and
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseUnary parses a negation, a parenthesized expression or a condition
+func (p *parser) parseUnary() (Node, error) {
+	tok := p.peek()
+	switch {
+	case tok.isKeyword("not"), tok.kind == tokLParen:
+		if p.depth >= MaxDepth {
+			return nil, p.errorAt(tok, "filter is nested more than %d levels deep", MaxDepth)
+		}
+		p.depth++
+		defer func() { p.depth-- }()
+	}
+
+	switch {
+	case tok.isKeyword("not"):
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{X: x}, nil
+	case tok.kind == tokLParen:
+		p.next()
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, p.errorAt(closing, "expected )")
+		}
+		return x, nil
+	default:
+		return p.parseCondition()
+	}
+}
+
+// parseCondition parses a field followed by an operator and its operands
+func (p *parser) parseCondition() (Node, error) {
+	name := p.next()
+	if name.kind != tokWord {
+		return nil, p.errorAt(name, "expected a field name")
+	}
+	field, ok := p.schema[name.text]
+	if !ok {
+		return nil, p.errorAt(name, "unknown field %q; fields are %s", name.text, p.fieldNames())
+	}
+	p.conditions++
+	if p.conditions > MaxConditions {
+		return nil, p.errorAt(name, "filter has more than %d conditions", MaxConditions)
+	}
+
+	cond := Condition{Field: name.text}
+	opTok := p.next()
+	switch {
+	case opTok.kind == tokOp:
+		cond.Op = Op(opTok.text)
+	case opTok.isKeyword("in"):
+		cond.Op = OpIn
+	case opTok.isKeyword("not"):
+		if in := p.next(); !in.isKeyword("in") {
+			return nil, p.errorAt(in, "expected in after not")
+		}
+		cond.Op = OpNotIn
+	case opTok.isKeyword("is"):
+		cond.Op = OpIsNull
+		if p.peek().isKeyword("not") {
+			p.next()
+			cond.Op = OpIsNotNull
+		}
+		if null := p.next(); !null.isKeyword("null") {
+			return nil, p.errorAt(null, "expected null")
+		}
+	default:
+		return nil, p.errorAt(opTok, "expected an operator after %s", name.text)
+	}
+
+	switch cond.Op {
+	case OpIsNull, OpIsNotNull:
+		if !field.Nullable {
+			return nil, p.errorAt(opTok, "%s is never null", name.text)
+		}
+		return cond, nil
+	}
+	if !slices.Contains(typeOps[field.Type], cond.Op) {
+		return nil, p.errorAt(opTok, "operator %s is not allowed on %s", cond.Op, name.text)
+	}
+
+	if cond.Op != OpIn && cond.Op != OpNotIn {
+		value, err := p.parseValue(name.text, field)
+		if err != nil {
+			return nil, err
+		}
+		cond.Values = []any{value}
+		return cond, nil
+	}
+
+	if open := p.next(); open.kind != tokLParen {
+		return nil, p.errorAt(open, "expected ( to start the list of values")
+	}
+	for {
+		if len(cond.Values) == MaxListValues {
+			return nil, p.errorAt(p.peek(), "list has more than %d values", MaxListValues)
+		}
+		value, err := p.parseValue(name.text, field)
+		if err != nil {
+			return nil, err
+		}
+		cond.Values = append(cond.Values, value)
+
+		sep := p.next()
+		if sep.kind == tokRParen {
+			return cond, nil
+		}
+		if sep.kind != tokComma {
+			return nil, p.errorAt(sep, "expected , or )")
+		}
+	}
+}
+
+// parseValue reads a value of the field's type
+func (p *parser) parseValue(name string, field Field) (any, error) {
+	tok := p.next()
+	if tok.kind != tokWord && tok.kind != tokString {
+		return nil, p.errorAt(tok, "expected a value for %s", name)
+	}
+
+	switch field.Type {
+	case String:
+		if len(field.Values) == 0 {
+			return tok.text, nil
+		}
+		for _, v := range field.Values {
+			if strings.EqualFold(v, tok.text) {
+				return v, nil
+			}
+		}
+		return nil, p.errorAt(tok, "invalid value for %s; values are %s", name, strings.Join(field.Values, ", "))
+	case Time:
+		t, ok := parseTime(tok.text, p.now)
+		if !ok {
+			return nil, p.errorAt(tok, "invalid time for %s; use now, now+7d, 2024-05-01 or an RFC 3339 timestamp", name)
+		}
+		return t, nil
+	case UUID:
+		id, err := uuid.Parse(tok.text)
+		if err != nil {
+			return nil, p.errorAt(tok, "invalid ID for %s", name)
+		}
+		return id, nil
+	default:
+		return tok.text, nil
+	}
+}
+
+// fieldNames lists the schema's field names in order
+func (p *parser) fieldNames() string {
+	names := make([]string, 0, len(p.schema))
+	for name := range p.schema {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
+
+// durationUnits maps the units of relative times to their length
+var durationUnits = map[byte]time.Duration{
+	's': time.Second,
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// parseTime reads now, now±N<unit>, a date or an RFC 3339 timestamp
+func parseTime(text string, now time.Time) (time.Time, bool) {
+	lower := strings.ToLower(text)
+	if lower == "now" {
+		return now, true
+	}
+	if rest, found := strings.CutPrefix(lower, "now"); found && len(rest) >= 3 && (rest[0] == '+' || rest[0] == '-') {
+		unit, ok := durationUnits[rest[len(rest)-1]]
+		if !ok {
+			return time.Time{}, false
+		}
+		// Offsets are capped per unit as well, so that they fit in a Duration
+		n, err := strconv.Atoi(rest[1 : len(rest)-1])
+		if err != nil || n < 0 || n > 100000 || int64(n) > math.MaxInt64/int64(unit) {
+			return time.Time{}, false
+		}
+		offset := time.Duration(n) * unit
+		if rest[0] == '-' {
+			offset = -offset
+		}
+		return now.Add(offset), true
+	}
+	if t, err := time.Parse(time.DateOnly, text); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.RFC3339, text); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
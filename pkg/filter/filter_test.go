@@ -0,0 +1,278 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var testSchema = Schema{
+	"status":   {Type: String, Values: []string{"Pending", "InProgress", "Completed"}},
+	"tag":      {Type: String},
+	"title":    {Type: Text},
+	"due_date": {Type: Time, Nullable: true},
+	"assignee": {Type: UUID, Nullable: true},
+}
+
+var testNow = time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+// render writes a parsed expression back out with every operation
+// parenthesized, so that trees can be compared as strings
+func render(n Node) string {
+	switch n := n.(type) {
+	case And:
+		return "(" + render(n.Left) + " and " + render(n.Right) + ")"
+	case Or:
+		return "(" + render(n.Left) + " or " + render(n.Right) + ")"
+	case Not:
+		return "(not " + render(n.X) + ")"
+	case Condition:
+		values := make([]string, len(n.Values))
+		for i, v := range n.Values {
+			if t, ok := v.(time.Time); ok {
+				values[i] = t.Format(time.RFC3339)
+			} else {
+				values[i] = fmt.Sprint(v)
+			}
+		}
+		switch n.Op {
+		case OpIsNull, OpIsNotNull:
+			return n.Field + " " + string(n.Op)
+		case OpIn, OpNotIn:
+			return n.Field + " " + string(n.Op) + " [" + strings.Join(values, ",") + "]"
+		}
+		return n.Field + " " + string(n.Op) + " " + values[0]
+	}
+	return fmt.Sprintf("%T", n)
+}
+
+func TestParse(t *testing.T) {
+	id := uuid.MustParse("5f0c6a3e-8f5a-4d8e-9d43-2f1b5c9e7a10")
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "and binds tighter than or",
+			input: "status = Pending or status = Completed and title ~ x",
+			want:  "(status = Pending or (status = Completed and title ~ x))",
+		},
+		{
+			name:  "and before or on the left",
+			input: "status = Pending and title ~ x or tag = a",
+			want:  "((status = Pending and title ~ x) or tag = a)",
+		},
+		{
+			name:  "parentheses override precedence",
+			input: "(status = Pending or status = Completed) and title ~ x",
+			want:  "((status = Pending or status = Completed) and title ~ x)",
+		},
+		{
+			name:  "not binds tighter than and",
+			input: "not status = Pending and title ~ x",
+			want:  "((not status = Pending) and title ~ x)",
+		},
+		{
+			name:  "not of a group",
+			input: "not (tag = a or tag = b)",
+			want:  "(not (tag = a or tag = b))",
+		},
+		{
+			name:  "or is left-associative",
+			input: "tag = a or tag = b or tag = c",
+			want:  "((tag = a or tag = b) or tag = c)",
+		},
+		{
+			name:  "keywords and values are case-insensitive",
+			input: "status IN (pending, COMPLETED) AND NOT tag = a",
+			want:  "(status in [Pending,Completed] and (not tag = a))",
+		},
+		{
+			name:  "not in",
+			input: "status not in (InProgress)",
+			want:  "status not in [InProgress]",
+		},
+		{
+			name:  "null tests",
+			input: "due_date is null or assignee is not null",
+			want:  "(due_date is null or assignee is not null)",
+		},
+		{
+			name:  "two-character operators",
+			input: "due_date<=now and due_date>=2024-04-01 and title!~x and tag!=a",
+			want:  "(((due_date <= 2024-05-01T12:00:00Z and due_date >= 2024-04-01T00:00:00Z) and title !~ x) and tag != a)",
+		},
+		{
+			name:  "quoted string with escapes",
+			input: `title = "say \"hi\" \\ bye"`,
+			want:  `title = say "hi" \ bye`,
+		},
+		{
+			name:  "relative times",
+			input: "due_date > now-12h and due_date < now+7d",
+			want:  "(due_date > 2024-05-01T00:00:00Z and due_date < 2024-05-08T12:00:00Z)",
+		},
+		{
+			name:  "RFC 3339 timestamp",
+			input: "due_date < 2024-05-01T10:00:00Z",
+			want:  "due_date < 2024-05-01T10:00:00Z",
+		},
+		{
+			name:  "largest week offset",
+			input: "due_date < now+15250w",
+			want:  "due_date < " + testNow.Add(15250*7*24*time.Hour).Format(time.RFC3339),
+		},
+		{
+			name:  "UUID",
+			input: "assignee = " + id.String(),
+			want:  "assignee = " + id.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.input, testSchema, testNow)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.input, err)
+			}
+			if got := render(node); got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		pos     int
+		token   string
+		message string
+	}{
+		{name: "empty", input: "  ", pos: 3, message: "filter is empty"},
+		{name: "unexpected character", input: "tag = a & tag = b", pos: 9, token: "&", message: "unexpected character"},
+		{name: "lone bang", input: "tag ! a", pos: 5, token: "!", message: "use != or !~"},
+		{name: "unterminated string", input: `title = "abc`, pos: 9, token: `"abc`, message: "unterminated string"},
+		{name: "positions count characters", input: `title = "été" and nope = 1`, pos: 19, token: "nope", message: "unknown field"},
+		{name: "unknown field", input: "nope = 1", pos: 1, token: "nope", message: "unknown field"},
+		{name: "missing field", input: "tag = a and", pos: 12, message: "expected a field name"},
+		{name: "missing operator", input: "tag a", pos: 5, token: "a", message: "expected an operator after tag"},
+		{name: "operator not allowed", input: "status < Pending", pos: 8, token: "<", message: "not allowed on status"},
+		{name: "match on a string field", input: "tag ~ a", pos: 5, token: "~", message: "not allowed on tag"},
+		{name: "never null", input: "title is null", pos: 7, token: "is", message: "title is never null"},
+		{name: "is without null", input: "due_date is not empty", pos: 17, token: "empty", message: "expected null"},
+		{name: "not without in", input: "status not (Pending)", pos: 12, token: "(", message: "expected in after not"},
+		{name: "invalid value", input: "status = Done", pos: 10, token: "Done", message: "values are Pending, InProgress, Completed"},
+		{name: "missing value", input: "tag = )", pos: 7, token: ")", message: "expected a value for tag"},
+		{name: "invalid time", input: "due_date < tomorrow", pos: 12, token: "tomorrow", message: "invalid time"},
+		{name: "unknown time unit", input: "due_date < now+7y", pos: 12, token: "now+7y", message: "invalid time"},
+		{name: "week offset overflowing", input: "due_date < now+15251w", pos: 12, token: "now+15251w", message: "invalid time"},
+		{name: "offset over the cap", input: "due_date < now+100001s", pos: 12, token: "now+100001s", message: "invalid time"},
+		{name: "invalid ID", input: "assignee = 42", pos: 12, token: "42", message: "invalid ID"},
+		{name: "list without parenthesis", input: "tag in a", pos: 8, token: "a", message: "expected ( to start"},
+		{name: "list without separator", input: "tag in (a b)", pos: 11, token: "b", message: "expected , or )"},
+		{name: "unclosed list", input: "tag in (a", pos: 10, message: "expected , or )"},
+		{name: "unclosed group", input: "(tag = a", pos: 9, message: "expected )"},
+		{name: "trailing token", input: "tag = a )", pos: 9, token: ")", message: "expected and, or or the end"},
+		{name: "missing conjunction", input: "tag = a tag = b", pos: 9, token: "tag", message: "expected and, or or the end"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input, testSchema, testNow)
+			var perr *Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("Parse(%q) error = %v, want an *Error", tt.input, err)
+			}
+			if perr.Pos != tt.pos || perr.Token != tt.token {
+				t.Errorf("error at %d %q, want %d %q", perr.Pos, perr.Token, tt.pos, tt.token)
+			}
+			if !strings.Contains(perr.Message, tt.message) {
+				t.Errorf("message %q, want it to contain %q", perr.Message, tt.message)
+			}
+		})
+	}
+}
+
+// conditions joins n conditions with or
+func conditions(n int) string {
+	terms := make([]string, n)
+	for i := range terms {
+		terms[i] = "tag = a"
+	}
+	return strings.Join(terms, " or ")
+}
+
+// nested wraps a condition in depth levels of parentheses
+func nested(depth int) string {
+	return strings.Repeat("(", depth) + "tag = a" + strings.Repeat(")", depth)
+}
+
+// negated prefixes a condition with depth nots
+func negated(depth int) string {
+	return strings.Repeat("not ", depth) + "tag = a"
+}
+
+// list builds an in condition over n values
+func list(n int) string {
+	values := make([]string, n)
+	for i := range values {
+		values[i] = fmt.Sprintf("v%d", i)
+	}
+	return "tag in (" + strings.Join(values, ",") + ")"
+}
+
+// ofLength builds a valid expression of n characters, most of them multibyte
+func ofLength(n int) string {
+	return `title ~ "` + strings.Repeat("é", n-len(`title ~ ""`)) + `"`
+}
+
+func TestParseLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+		pos     int
+		message string
+	}{
+		{name: "longest filter", input: ofLength(MaxLength)},
+		{name: "filter too long", input: ofLength(MaxLength + 1), wantErr: true, pos: MaxLength + 1, message: "longer than"},
+		{name: "most conditions", input: conditions(MaxConditions)},
+		{name: "too many conditions", input: conditions(MaxConditions + 1), wantErr: true, pos: len(conditions(MaxConditions)) + len(" or ") + 1, message: "more than 50 conditions"},
+		{name: "deepest nesting", input: nested(MaxDepth)},
+		{name: "nested too deep", input: nested(MaxDepth + 1), wantErr: true, pos: MaxDepth + 1, message: "nested more than"},
+		{name: "deepest negation", input: negated(MaxDepth)},
+		{name: "negated too deep", input: negated(MaxDepth + 1), wantErr: true, pos: 4*MaxDepth + 1, message: "nested more than"},
+		{name: "longest list", input: list(MaxListValues)},
+		{name: "list too long", input: list(MaxListValues + 1), wantErr: true, message: "list has more than"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input, testSchema, testNow)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Parse: %v", err)
+				}
+				return
+			}
+			var perr *Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("error = %v, want an *Error", err)
+			}
+			if tt.pos != 0 && perr.Pos != tt.pos {
+				t.Errorf("error at %d, want %d", perr.Pos, tt.pos)
+			}
+			if !strings.Contains(perr.Message, tt.message) {
+				t.Errorf("message %q, want it to contain %q", perr.Message, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor packs the sort key values of the last item on a page into an
+// opaque, URL-safe string
+func EncodeCursor(values ...string) string {
+	data, _ := json.Marshal(values)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor unpacks a cursor produced by EncodeCursor, expecting exactly n values
+func DecodeCursor(cursor string, n int) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil || len(values) != n {
+		return nil, ErrInvalidCursor
+	}
+
+	return values, nil
+}
+
+// Signer encodes cursors like EncodeCursor but appends an HMAC-SHA256
+// signature, so that clients can pass cursors back but not forge them
+type Signer struct {
+	key []byte
+}
+
+// NewSigner creates a signer with the given secret key
+func NewSigner(key []byte) *Signer {
+	return &Signer{key: key}
+}
+
+// EncodeCursor packs values into a signed, opaque, URL-safe string
+func (s *Signer) EncodeCursor(values ...string) string {
+	payload := EncodeCursor(values...)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(s.sign(payload))
+}
+
+// DecodeCursor verifies a cursor produced by EncodeCursor and unpacks it,
+// expecting exactly n values
+func (s *Signer) DecodeCursor(cursor string, n int) ([]string, error) {
+	payload, signature, ok := strings.Cut(cursor, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.sign(payload)) {
+		return nil, ErrInvalidCursor
+	}
+	return DecodeCursor(payload, n)
+}
+
+// sign computes the signature of a cursor payload
+func (s *Signer) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
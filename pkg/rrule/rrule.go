@@ -0,0 +1,411 @@
+// Package rrule parses and evaluates the subset of iCalendar recurrence rules
+// (RFC 5545, section 3.3.10) made of FREQ, INTERVAL, BYDAY, BYMONTHDAY, COUNT
+// and UNTIL.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRule is wrapped by every error returned from Parse
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// maxPeriods bounds the number of periods searched for the next occurrence,
+// so that rules whose filters never match cannot loop forever
+const maxPeriods = 2000
+
+// untilLayout is the UTC date-time form of UNTIL
+const untilLayout = "20060102T150405Z"
+
+// Frequency is the period a rule repeats over
+type Frequency string
+
+// Frequency constants
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// weekdayCodes maps the two-letter BYDAY codes to weekdays
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Weekday is an entry of BYDAY. N selects the Nth such weekday of the month,
+// counting from the end when negative; zero selects every one.
+type Weekday struct {
+	Day time.Weekday
+	N   int
+}
+
+// String formats the weekday as in BYDAY, e.g. "MO" or "-1FR"
+func (w Weekday) String() string {
+	code := strings.ToUpper(w.Day.String()[:2])
+	if w.N == 0 {
+		return code
+	}
+	return strconv.Itoa(w.N) + code
+}
+
+// Rule is a parsed recurrence rule. Occurrences are computed in the time zone
+// of the start time they are evaluated from.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []Weekday
+	ByMonthDay []int
+	// Count limits the series to this many occurrences, the start included; zero means unlimited
+	Count int
+	// Until is the last instant an occurrence may fall on, if set
+	Until *time.Time
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=10".
+// A leading "RRULE:" is ignored.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 6 && strings.EqualFold(s[:6], "RRULE:") {
+		s = s[6:]
+	}
+	if s == "" {
+		return nil, fmt.Errorf("%w: rule is empty", ErrInvalidRule)
+	}
+
+	rule := &Rule{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: %q is not of the form NAME=VALUE", ErrInvalidRule, part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: %s is given more than once", ErrInvalidRule, name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Freq = Frequency(value)
+			if rule.Freq != Daily && rule.Freq != Weekly && rule.Freq != Monthly && rule.Freq != Yearly {
+				err = fmt.Errorf("FREQ must be DAILY, WEEKLY, MONTHLY or YEARLY")
+			}
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(name, value)
+		case "COUNT":
+			rule.Count, err = parsePositive(name, value)
+		case "UNTIL":
+			rule.Until, err = parseUntil(value)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseByMonthDay(value)
+		default:
+			err = fmt.Errorf("%s is not supported", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+
+	if err := rule.check(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+
+	return rule, nil
+}
+
+// check rejects combinations of parts that the rule cannot express
+func (r *Rule) check() error {
+	if r.Freq == "" {
+		return errors.New("FREQ is required")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return errors.New("COUNT and UNTIL cannot be combined")
+	}
+	for _, day := range r.ByDay {
+		if day.N != 0 && r.Freq != Monthly {
+			return errors.New("numbered BYDAY entries are only supported with FREQ=MONTHLY")
+		}
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq == Weekly {
+		return errors.New("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+	}
+	if r.Freq == Yearly && (len(r.ByDay) > 0 || len(r.ByMonthDay) > 0) {
+		return errors.New("BYDAY and BYMONTHDAY are not supported with FREQ=YEARLY")
+	}
+	return nil
+}
+
+// String formats the rule in canonical form, without the "RRULE:" prefix
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence after start, where start is itself the
+// first occurrence of the series. It reports false once the series has ended.
+func (r Rule) Next(start time.Time) (time.Time, bool) {
+	occurrences := r.Occurrences(start, 1)
+	if len(occurrences) == 0 {
+		return time.Time{}, false
+	}
+	return occurrences[0], true
+}
+
+// Occurrences returns up to n occurrences following start, where start is
+// itself the first occurrence of the series
+func (r Rule) Occurrences(start time.Time, n int) []time.Time {
+	if r.Count > 0 && n > r.Count-1 {
+		n = r.Count - 1
+	}
+
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	occurrences := []time.Time{}
+	for period := 0; period < maxPeriods && len(occurrences) < n; period++ {
+		for _, t := range r.expand(start, period*interval) {
+			if !t.After(start) {
+				continue
+			}
+			if r.Until != nil && t.After(*r.Until) {
+				return occurrences
+			}
+			occurrences = append(occurrences, t)
+			if len(occurrences) == n {
+				break
+			}
+		}
+	}
+
+	return occurrences
+}
+
+// expand lists, in order, the candidate occurrences of the period lying
+// offset frequency units after the one containing start
+func (r Rule) expand(start time.Time, offset int) []time.Time {
+	y, m, d := start.Date()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	}
+
+	switch r.Freq {
+	case Daily:
+		day := at(y, m, d+offset)
+		if r.matchesWeekday(day) && r.matchesMonthDay(day) {
+			return []time.Time{day}
+		}
+		return nil
+
+	case Weekly:
+		// Weeks start on Monday
+		monday := d - (int(start.Weekday())+6)%7 + offset*7
+		weekdays := []time.Weekday{start.Weekday()}
+		if len(r.ByDay) > 0 {
+			weekdays = weekdays[:0]
+			for _, day := range r.ByDay {
+				weekdays = append(weekdays, day.Day)
+			}
+		}
+		days := make([]time.Time, 0, len(weekdays))
+		for _, weekday := range weekdays {
+			days = append(days, at(y, m, monday+(int(weekday)+6)%7))
+		}
+		return sortedUnique(days)
+
+	case Monthly:
+		first := time.Date(y, m+time.Month(offset), 1, 0, 0, 0, 0, start.Location())
+		year, month := first.Year(), first.Month()
+		length := daysIn(year, month)
+
+		var days []time.Time
+		for day := 1; day <= length; day++ {
+			candidate := at(year, month, day)
+			switch {
+			case len(r.ByDay) == 0 && len(r.ByMonthDay) == 0:
+				if day != d {
+					continue
+				}
+			case !r.matchesMonthDay(candidate) || !r.matchesMonthlyWeekday(candidate, length):
+				continue
+			}
+			days = append(days, candidate)
+		}
+		return days
+
+	case Yearly:
+		year := y + offset
+		if d > daysIn(year, m) {
+			return nil
+		}
+		return []time.Time{at(year, m, d)}
+	}
+
+	return nil
+}
+
+// matchesWeekday applies an unnumbered BYDAY filter
+func (r Rule) matchesWeekday(t time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day.Day == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMonthlyWeekday applies BYDAY within a month of the given length,
+// honouring numbered entries such as "2TU" and "-1FR"
+func (r Rule) matchesMonthlyWeekday(t time.Time, length int) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day.Day != t.Weekday() {
+			continue
+		}
+		switch {
+		case day.N == 0:
+			return true
+		case day.N > 0 && (t.Day()-1)/7+1 == day.N:
+			return true
+		case day.N < 0 && (length-t.Day())/7+1 == -day.N:
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMonthDay applies BYMONTHDAY, where negative days count from the end of the month
+func (r Rule) matchesMonthDay(t time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	length := daysIn(t.Year(), t.Month())
+	for _, day := range r.ByMonthDay {
+		if day == t.Day() || (day < 0 && length+1+day == t.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePositive(name, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return n, nil
+}
+
+// parseUntil accepts a UTC date-time, a floating date-time (read as UTC) or
+// a date, which includes the whole day
+func parseUntil(value string) (*time.Time, error) {
+	for _, layout := range []string{untilLayout, "20060102T150405"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		end := t.Add(24*time.Hour - time.Second)
+		return &end, nil
+	}
+	return nil, errors.New("UNTIL must be a date (YYYYMMDD) or a UTC date-time (YYYYMMDDTHHMMSSZ)")
+}
+
+func parseByDay(value string) ([]Weekday, error) {
+	var days []Weekday
+	for _, entry := range strings.Split(value, ",") {
+		if len(entry) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY entry %q", entry)
+		}
+		code, ordinal := entry[len(entry)-2:], entry[:len(entry)-2]
+		weekday, ok := weekdayCodes[code]
+		if !ok {
+			return nil, fmt.Errorf("invalid BYDAY entry %q", entry)
+		}
+		day := Weekday{Day: weekday}
+		if ordinal != "" {
+			n, err := strconv.Atoi(ordinal)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("invalid BYDAY entry %q", entry)
+			}
+			day.N = n
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+	for _, entry := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(entry)
+		if err != nil || n == 0 || n < -31 || n > 31 {
+			return nil, fmt.Errorf("invalid BYMONTHDAY entry %q", entry)
+		}
+		days = append(days, n)
+	}
+	return days, nil
+}
+
+// daysIn returns the number of days of a month
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// sortedUnique orders times and drops duplicates
+func sortedUnique(times []time.Time) []time.Time {
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	unique := times[:0]
+	for i, t := range times {
+		if i == 0 || !t.Equal(times[i-1]) {
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
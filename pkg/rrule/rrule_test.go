@@ -0,0 +1,208 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+}
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		n     int
+		want  []time.Time
+	}{
+		{
+			name:  "count includes the start",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: date(2024, time.January, 1),
+			n:     10,
+			want:  []time.Time{date(2024, time.January, 2), date(2024, time.January, 3)},
+		},
+		{
+			name:  "count of one ends at the start",
+			rule:  "FREQ=DAILY;COUNT=1",
+			start: date(2024, time.January, 1),
+			n:     10,
+			want:  []time.Time{},
+		},
+		{
+			name:  "until date includes the whole day",
+			rule:  "FREQ=WEEKLY;UNTIL=20240115",
+			start: date(2024, time.January, 1),
+			n:     10,
+			want:  []time.Time{date(2024, time.January, 8), date(2024, time.January, 15)},
+		},
+		{
+			name:  "until date-time is inclusive",
+			rule:  "FREQ=WEEKLY;UNTIL=20240115T090000Z",
+			start: date(2024, time.January, 1),
+			n:     10,
+			want:  []time.Time{date(2024, time.January, 8), date(2024, time.January, 15)},
+		},
+		{
+			name:  "until date-time before the occurrence excludes it",
+			rule:  "FREQ=WEEKLY;UNTIL=20240115T085959Z",
+			start: date(2024, time.January, 1),
+			n:     10,
+			want:  []time.Time{date(2024, time.January, 8)},
+		},
+		{
+			name:  "n caps the occurrences",
+			rule:  "FREQ=WEEKLY;INTERVAL=2",
+			start: date(2024, time.January, 1),
+			n:     2,
+			want:  []time.Time{date(2024, time.January, 15), date(2024, time.January, 29)},
+		},
+		{
+			name:  "weekly by day",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE",
+			start: date(2024, time.January, 1),
+			n:     3,
+			want:  []time.Time{date(2024, time.January, 3), date(2024, time.January, 8), date(2024, time.January, 10)},
+		},
+		{
+			name:  "month day 31 skips short months",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=31",
+			start: date(2024, time.January, 31),
+			n:     4,
+			want:  []time.Time{date(2024, time.March, 31), date(2024, time.May, 31), date(2024, time.July, 31), date(2024, time.August, 31)},
+		},
+		{
+			name:  "month day -1 is the last day of each month",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: date(2024, time.January, 31),
+			n:     4,
+			want:  []time.Time{date(2024, time.February, 29), date(2024, time.March, 31), date(2024, time.April, 30), date(2024, time.May, 31)},
+		},
+		{
+			name:  "month day -1 in a common year February",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: date(2023, time.January, 31),
+			n:     1,
+			want:  []time.Time{date(2023, time.February, 28)},
+		},
+		{
+			name:  "monthly without filters skips months lacking the day",
+			rule:  "FREQ=MONTHLY",
+			start: date(2024, time.January, 31),
+			n:     2,
+			want:  []time.Time{date(2024, time.March, 31), date(2024, time.May, 31)},
+		},
+		{
+			name:  "last Friday",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR",
+			start: date(2024, time.January, 26),
+			n:     3,
+			want:  []time.Time{date(2024, time.February, 23), date(2024, time.March, 29), date(2024, time.April, 26)},
+		},
+		{
+			name:  "second Tuesday",
+			rule:  "FREQ=MONTHLY;BYDAY=2TU",
+			start: date(2024, time.January, 9),
+			n:     3,
+			want:  []time.Time{date(2024, time.February, 13), date(2024, time.March, 12), date(2024, time.April, 9)},
+		},
+		{
+			name:  "yearly skips February 29 in common years",
+			rule:  "FREQ=YEARLY",
+			start: date(2024, time.February, 29),
+			n:     1,
+			want:  []time.Time{date(2028, time.February, 29)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+			got := rule.Occurrences(tt.start, tt.n)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Fatalf("Occurrences = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   string
+		start  time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		{"next of the series", "FREQ=DAILY;COUNT=2", date(2024, time.January, 1), date(2024, time.January, 2), true},
+		{"series ended by count", "FREQ=DAILY;COUNT=1", date(2024, time.January, 1), time.Time{}, false},
+		{"series ended by until", "FREQ=DAILY;UNTIL=20240101", date(2024, time.January, 1), time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+			got, ok := rule.Next(tt.start)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("Next = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "canonical form", rule: "RRULE:freq=monthly;byday=-1fr,2tu;interval=1;count=5", want: "FREQ=MONTHLY;BYDAY=-1FR,2TU;COUNT=5"},
+		{name: "until date-time", rule: "FREQ=DAILY;UNTIL=20240115T090000Z", want: "FREQ=DAILY;UNTIL=20240115T090000Z"},
+		{name: "empty", rule: " ", wantErr: true},
+		{name: "missing freq", rule: "COUNT=3", wantErr: true},
+		{name: "unknown freq", rule: "FREQ=HOURLY", wantErr: true},
+		{name: "repeated part", rule: "FREQ=DAILY;FREQ=WEEKLY", wantErr: true},
+		{name: "unsupported part", rule: "FREQ=DAILY;BYHOUR=9", wantErr: true},
+		{name: "count and until", rule: "FREQ=DAILY;COUNT=3;UNTIL=20240115", wantErr: true},
+		{name: "zero count", rule: "FREQ=DAILY;COUNT=0", wantErr: true},
+		{name: "numbered day outside monthly", rule: "FREQ=WEEKLY;BYDAY=2TU", wantErr: true},
+		{name: "ordinal out of range", rule: "FREQ=MONTHLY;BYDAY=6TU", wantErr: true},
+		{name: "month day out of range", rule: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{name: "month day zero", rule: "FREQ=MONTHLY;BYMONTHDAY=0", wantErr: true},
+		{name: "month day with weekly", rule: "FREQ=WEEKLY;BYMONTHDAY=1", wantErr: true},
+		{name: "filters with yearly", rule: "FREQ=YEARLY;BYDAY=MO", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRule) {
+					t.Fatalf("Parse(%q) error = %v, want ErrInvalidRule", tt.rule, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+			if got := rule.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}